@@ -0,0 +1,60 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand
+
+import "math/big"
+
+// BigIntn generates a random big integer in range [0,max).
+// It uses math rand, so the result is not suitable for cryptographic purposes;
+// see [SecureBigIntn] for that.
+// It panics if max <= 0.
+func BigIntn(max *big.Int) *big.Int {
+	n, _ := bigIntn(max, readMathRand)
+
+	return n
+}
+
+// SecureBigIntn generates a cryptographically secure random big integer in range [0,max).
+// It uses crypto/rand and returns an error if reading from it fails.
+// It panics if max <= 0.
+func SecureBigIntn(max *big.Int) (*big.Int, error) {
+	return bigIntn(max, readCryptoRand)
+}
+
+// bigIntn generates a random big integer in range [0,max) using rejection sampling:
+// it reads just enough random bytes to cover max-1's bits, masks off the exceeding bits of
+// the most significant byte, and retries until the resulting number is lower than max.
+// The probability of a retry is always < 1/2.
+func bigIntn(max *big.Int, read func([]byte) error) (*big.Int, error) {
+	// Note: implementation is similar to crypto/rand.Int, but works with any bytes reader.
+	if max.Sign() <= 0 {
+		panic("invalid argument to BigIntn")
+	}
+
+	bitLen := new(big.Int).Sub(max, big.NewInt(1)).BitLen()
+	if bitLen == 0 { // max == 1
+		return new(big.Int), nil
+	}
+
+	var (
+		msbBits = uint(bitLen % 8) // no. of bits used from the most significant byte
+		b       = make([]byte, (bitLen+7)/8)
+		n       = new(big.Int)
+	)
+	if msbBits == 0 {
+		msbBits = 8
+	}
+	for {
+		if err := read(b); err != nil {
+			return nil, err
+		}
+		b[0] &= uint8(int(1<<msbBits) - 1)
+		n.SetBytes(b)
+		if n.Cmp(max) < 0 {
+			return n, nil
+		}
+	}
+}
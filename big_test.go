@@ -0,0 +1,130 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/actforgood/xrand"
+)
+
+// bigMaxes is a list of (large) maxes to test big integer generators with.
+var bigMaxes = [...]*big.Int{
+	big.NewInt(1),
+	big.NewInt(255),
+	big.NewInt(256),
+	new(big.Int).Lsh(big.NewInt(1), 64), // 2^64
+	new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 100), big.NewInt(7)), // 2^100 + 7
+	new(big.Int).Exp(big.NewInt(10), big.NewInt(30), nil),                 // 10^30
+}
+
+func TestBigIntn(t *testing.T) {
+	t.Parallel()
+
+	t.Run("result is in range", func(t *testing.T) {
+		t.Parallel()
+
+		testBigIntnInRange(t, func(max *big.Int) (*big.Int, error) {
+			return xrand.BigIntn(max), nil
+		})
+	})
+	t.Run("result is uniform", func(t *testing.T) {
+		t.Parallel()
+
+		testBigIntnIsUniform(t, func(max *big.Int) (*big.Int, error) {
+			return xrand.BigIntn(max), nil
+		})
+	})
+}
+
+func TestSecureBigIntn(t *testing.T) {
+	t.Parallel()
+
+	t.Run("result is in range", func(t *testing.T) {
+		t.Parallel()
+
+		testBigIntnInRange(t, xrand.SecureBigIntn)
+	})
+	t.Run("result is uniform", func(t *testing.T) {
+		t.Parallel()
+
+		testBigIntnIsUniform(t, xrand.SecureBigIntn)
+	})
+}
+
+func testBigIntnInRange(t *testing.T, subject func(*big.Int) (*big.Int, error)) {
+	t.Helper()
+
+	for _, testData := range bigMaxes {
+		max := testData // capture range variable
+		t.Run(fmt.Sprintf("[0,%s)", max), func(t *testing.T) {
+			for i := 0; i < 1000; i++ {
+				// act
+				result, err := subject(max)
+
+				// assert
+				assertNil(t, err)
+				assertTrue(t, result.Sign() >= 0)
+				assertTrue(t, result.Cmp(max) < 0)
+			}
+		})
+	}
+}
+
+func testBigIntnIsUniform(t *testing.T, subject func(*big.Int) (*big.Int, error)) {
+	t.Helper()
+
+	// arrange
+	const (
+		buckets    = 10
+		iterations = 20000
+	)
+	var (
+		max    = big.NewInt(buckets)
+		counts = make([]int, buckets)
+	)
+
+	// act
+	for i := 0; i < iterations; i++ {
+		result, err := subject(max)
+		assertNil(t, err)
+		counts[result.Int64()]++
+	}
+
+	// assert
+	assertUniform(t, counts, iterations, 0.1)
+}
+
+func BenchmarkBigIntn(b *testing.B) {
+	max := new(big.Int).Lsh(big.NewInt(1), 100)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = xrand.BigIntn(max)
+	}
+}
+
+func ExampleBigIntn() {
+	// generate a random big int in [0, 10^30)
+	max := new(big.Int).Exp(big.NewInt(10), big.NewInt(30), nil)
+	randBigInt := xrand.BigIntn(max)
+	fmt.Println(randBigInt)
+}
+
+func ExampleSecureBigIntn() {
+	// generate a cryptographically secure random big int in [0, 2^256)
+	max := new(big.Int).Lsh(big.NewInt(1), 256)
+	randBigInt, err := xrand.SecureBigIntn(max)
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+	fmt.Println(randBigInt)
+}
@@ -0,0 +1,154 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand
+
+import "sync"
+
+// flipsBatchSize is the no. of random int63 numbers [Flips] pulls from the source at once.
+const flipsBatchSize = 64
+
+// Flips returns count biased coin flips, each being true with probability p.
+// If p <= 0.0, all flips are false. If p >= 1.0, all flips are true.
+// It pulls random numbers in batches from the source, being faster than
+// drawing a [Float64] for every flip.
+func Flips(count int, p float64) []bool {
+	flips := make([]bool, count)
+	if p <= 0.0 {
+		return flips
+	}
+	if p >= 1.0 {
+		for i := range flips {
+			flips[i] = true
+		}
+
+		return flips
+	}
+
+	var (
+		threshold = int64(p * (1 << 63)) // a random int63 lower than threshold has p probability.
+		batch     [flipsBatchSize]int64
+	)
+	for i := 0; i < count; i += flipsBatchSize {
+		globalSource.int63s(batch[:])
+		for j := 0; j < flipsBatchSize && i+j < count; j++ {
+			flips[i+j] = batch[j] < threshold
+		}
+	}
+
+	return flips
+}
+
+// BoolGrid returns a rows x cols grid of random booleans, each cell being true with probability density,
+// useful for seeding cellular automata, like Game of Life.
+// Density is clamped to [0.0, 1.0], meaning density <= 0.0 (or NaN) produces an all false grid,
+// while density >= 1.0 produces an all true one.
+// Like [Flips], it pulls random numbers in batches from the source, across rows.
+// Each row is allocated separately, rows do not share backing arrays.
+// It panics if rows < 0 or cols < 0.
+func BoolGrid(rows, cols int, density float64) [][]bool {
+	if rows < 0 || cols < 0 {
+		panic("invalid argument to BoolGrid")
+	}
+
+	grid := make([][]bool, rows)
+	for i := range grid {
+		grid[i] = make([]bool, cols)
+	}
+	if !(density > 0.0) { // Note: negated condition also catches NaN.
+		return grid
+	}
+	if density >= 1.0 {
+		for _, row := range grid {
+			for j := range row {
+				row[j] = true
+			}
+		}
+
+		return grid
+	}
+
+	var (
+		threshold = int64(density * (1 << 63)) // a random int63 lower than threshold has density probability.
+		batch     [flipsBatchSize]int64
+		pos       = flipsBatchSize
+	)
+	for _, row := range grid {
+		for j := range row {
+			if pos == flipsBatchSize {
+				globalSource.int63s(batch[:])
+				pos = 0
+			}
+			row[j] = batch[pos] < threshold
+			pos++
+		}
+	}
+
+	return grid
+}
+
+// OneInN returns true with a probability of 1/n, like "roughly one in a thousand requests":
+// OneInN(1000). It is an alternative to expressing the probability as a float.
+// For n = 1, it always returns true.
+// It panics if n <= 0.
+func OneInN(n int) bool {
+	if n <= 0 {
+		panic("invalid argument to OneInN")
+	}
+
+	return globalRand.Intn(n) == 0
+}
+
+// DriftingBool generates random booleans, whose probability of being true drifts linearly,
+// call by call, from a start probability to an end probability, and then stays at end probability.
+// It is useful, for example, to simulate a dependency getting flakier (or recovering) over time.
+// It is safe for concurrent use by multiple goroutines.
+type DriftingBool struct {
+	mu    sync.Mutex
+	start float64
+	end   float64
+	steps int
+	calls int
+}
+
+// NewDriftingBool instantiates a new DriftingBool, whose probability of being true drifts from start
+// (first call) to end (after steps calls).
+// If steps <= 0, the probability is end from the first call.
+func NewDriftingBool(start, end float64, steps int) *DriftingBool {
+	return &DriftingBool{
+		start: start,
+		end:   end,
+		steps: steps,
+	}
+}
+
+// Next returns a random boolean, true with the current probability.
+func (db *DriftingBool) Next() bool {
+	db.mu.Lock()
+	p := db.probability()
+	if db.calls < db.steps {
+		db.calls++
+	}
+	db.mu.Unlock()
+
+	return Float64() < p
+}
+
+// Probability returns the probability of the next call to Next returning true.
+func (db *DriftingBool) Probability() float64 {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	return db.probability()
+}
+
+// probability returns the current probability. Should be called under lock.
+func (db *DriftingBool) probability() float64 {
+	if db.calls >= db.steps {
+		return db.end
+	}
+
+	return db.start + (db.end-db.start)*float64(db.calls)/float64(db.steps)
+}
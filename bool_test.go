@@ -0,0 +1,339 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/actforgood/xrand"
+)
+
+func TestFlips(t *testing.T) {
+	t.Parallel()
+
+	t.Run("true rate matches probability", testFlipsRate)
+	t.Run("boundary probabilities", testFlipsBoundaries)
+}
+
+func testFlipsRate(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const count = 100000
+	subject := xrand.Flips
+
+	for _, testData := range [...]float64{0.01, 0.1, 0.5, 0.73, 0.99} {
+		p := testData // capture range variable
+		t.Run(fmt.Sprintf("p = %.2f", p), func(t *testing.T) {
+			// act
+			result := subject(count, p)
+
+			// assert
+			assertEqual(t, count, len(result))
+			assertTrue(t, math.Abs(trueRate(result)-p) < 0.01)
+		})
+	}
+}
+
+func testFlipsBoundaries(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	subject := xrand.Flips
+	tests := [...]struct {
+		name         string
+		inputP       float64
+		expectedRate float64
+	}{
+		{name: "p = 0", inputP: 0, expectedRate: 0},
+		{name: "p < 0", inputP: -0.5, expectedRate: 0},
+		{name: "p = 1", inputP: 1, expectedRate: 1},
+		{name: "p > 1", inputP: 1.5, expectedRate: 1},
+	}
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			// act
+			result := subject(1000, test.inputP)
+
+			// assert
+			assertEqual(t, 1000, len(result))
+			assertEqual(t, test.expectedRate, trueRate(result))
+		})
+	}
+}
+
+func TestBoolGrid(t *testing.T) {
+	t.Parallel()
+
+	t.Run("dimensions and true rate", testBoolGridRate)
+	t.Run("boundary densities", testBoolGridBoundaries)
+	t.Run("rows do not share backing arrays", testBoolGridRowsAreIndependent)
+	t.Run("panics for negative dimensions", testBoolGridPanics)
+}
+
+func testBoolGridRate(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const rows, cols = 250, 401 // Note: cols is not a multiple of the batch size.
+
+	for _, testData := range [...]float64{0.05, 0.3, 0.5, 0.9} {
+		density := testData // capture range variable
+		t.Run(fmt.Sprintf("density = %.2f", density), func(t *testing.T) {
+			// act
+			result := xrand.BoolGrid(rows, cols, density)
+
+			// assert
+			assertEqual(t, rows, len(result))
+			cells := make([]bool, 0, rows*cols)
+			for _, row := range result {
+				assertEqual(t, cols, len(row))
+				cells = append(cells, row...)
+			}
+			assertTrue(t, math.Abs(trueRate(cells)-density) < 0.01)
+		})
+	}
+}
+
+func testBoolGridBoundaries(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	tests := [...]struct {
+		name         string
+		inputDensity float64
+		expectedRate float64
+	}{
+		{name: "density = 0", inputDensity: 0, expectedRate: 0},
+		{name: "density < 0", inputDensity: -0.5, expectedRate: 0},
+		{name: "density NaN", inputDensity: math.NaN(), expectedRate: 0},
+		{name: "density = 1", inputDensity: 1, expectedRate: 1},
+		{name: "density > 1", inputDensity: 1.5, expectedRate: 1},
+	}
+
+	for _, test := range tests {
+		// act
+		result := xrand.BoolGrid(10, 20, test.inputDensity)
+
+		// assert
+		assertEqual(t, 10, len(result))
+		for _, row := range result {
+			assertEqual(t, 20, len(row))
+			assertEqual(t, test.expectedRate, trueRate(row))
+		}
+	}
+
+	// act & assert empty grids
+	assertEqual(t, 0, len(xrand.BoolGrid(0, 10, 0.5)))
+	for _, row := range xrand.BoolGrid(3, 0, 0.5) {
+		assertEqual(t, 0, len(row))
+	}
+}
+
+func testBoolGridRowsAreIndependent(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	subject := xrand.BoolGrid(3, 4, 1)
+
+	// act
+	subject[0] = append(subject[0], false, false)
+	subject[1][0] = false
+
+	// assert
+	assertEqual(t, 1.0, trueRate(subject[2]))
+	assertEqual(t, 0.75, trueRate(subject[1]))
+	for i := 1; i < len(subject); i++ {
+		assertTrue(t, &subject[i][0] != &subject[i-1][0])
+	}
+}
+
+func testBoolGridPanics(t *testing.T) {
+	t.Parallel()
+
+	// act & assert
+	assertPanics(t, func() { _ = xrand.BoolGrid(-1, 10, 0.5) })
+	assertPanics(t, func() { _ = xrand.BoolGrid(10, -1, 0.5) })
+}
+
+func TestOneInN(t *testing.T) {
+	t.Parallel()
+
+	t.Run("true rate matches 1/n", testOneInNRate)
+	t.Run("boundaries", testOneInNBoundaries)
+}
+
+func testOneInNRate(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const count = 200000
+	subject := xrand.OneInN
+
+	for _, testData := range [...]int{2, 3, 10, 100, 1000} {
+		n := testData // capture range variable
+		t.Run(fmt.Sprintf("n = %d", n), func(t *testing.T) {
+			results := make([]bool, count)
+			for i := range results {
+				// act
+				results[i] = subject(n)
+			}
+
+			// assert
+			expected := 1 / float64(n)
+			assertTrue(t, math.Abs(trueRate(results)-expected) < 0.1*expected+0.001)
+		})
+	}
+}
+
+func testOneInNBoundaries(t *testing.T) {
+	t.Parallel()
+
+	// act & assert
+	for i := 0; i < 100; i++ {
+		assertTrue(t, xrand.OneInN(1))
+	}
+	assertPanics(t, func() { _ = xrand.OneInN(0) })
+	assertPanics(t, func() { _ = xrand.OneInN(-10) })
+}
+
+func TestDriftingBool(t *testing.T) {
+	t.Parallel()
+
+	t.Run("probability drifts linearly", testDriftingBoolProbability)
+	t.Run("early calls have start probability", testDriftingBoolStart)
+	t.Run("late calls have end probability", testDriftingBoolEnd)
+	t.Run("no steps", testDriftingBoolNoSteps)
+}
+
+func testDriftingBoolProbability(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	subject := xrand.NewDriftingBool(0.9, 0.1, 4)
+	expected := [...]float64{0.9, 0.7, 0.5, 0.3, 0.1, 0.1, 0.1}
+
+	for _, p := range expected {
+		// act & assert
+		assertTrue(t, math.Abs(p-subject.Probability()) < 1e-9)
+		_ = subject.Next()
+	}
+}
+
+func testDriftingBoolStart(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const count = 20000
+	results := make([]bool, count)
+
+	// act
+	for i := range results {
+		results[i] = xrand.NewDriftingBool(0.9, 0.1, 100).Next()
+	}
+
+	// assert
+	assertTrue(t, math.Abs(trueRate(results)-0.9) < 0.02)
+}
+
+func testDriftingBoolEnd(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const (
+		count = 20000
+		steps = 100
+	)
+	var (
+		subject = xrand.NewDriftingBool(0.9, 0.1, steps)
+		results = make([]bool, count)
+	)
+	for i := 0; i < steps; i++ {
+		_ = subject.Next()
+	}
+
+	// act
+	for i := range results {
+		results[i] = subject.Next()
+	}
+
+	// assert
+	assertTrue(t, math.Abs(trueRate(results)-0.1) < 0.02)
+	assertEqual(t, 0.1, subject.Probability())
+}
+
+func testDriftingBoolNoSteps(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	subject := xrand.NewDriftingBool(0, 1, 0)
+
+	for i := 0; i < 100; i++ {
+		// act & assert
+		assertTrue(t, subject.Next())
+	}
+}
+
+// trueRate returns the fraction of true values.
+func trueRate(values []bool) float64 {
+	trues := 0
+	for _, value := range values {
+		if value {
+			trues++
+		}
+	}
+
+	return float64(trues) / float64(len(values))
+}
+
+func BenchmarkFlips(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = xrand.Flips(1000, 0.3)
+	}
+}
+
+func ExampleFlips() {
+	// simulate 10 requests over an unreliable link, with 90% success rate.
+	for i, success := range xrand.Flips(10, 0.9) {
+		fmt.Println(i, success)
+	}
+}
+
+func ExampleBoolGrid() {
+	// seed a Game of Life board, with a quarter of the cells alive.
+	for _, row := range xrand.BoolGrid(4, 8, 0.25) {
+		for _, alive := range row {
+			if alive {
+				fmt.Print("#")
+			} else {
+				fmt.Print(".")
+			}
+		}
+		fmt.Println()
+	}
+}
+
+func ExampleOneInN() {
+	// log roughly one in a thousand requests.
+	if xrand.OneInN(1000) {
+		fmt.Println("sampled request")
+	}
+}
+
+func ExampleDriftingBool() {
+	// simulate a dependency which gets flakier, from 99% success rate to 50%, over 1000 calls.
+	dependencyUp := xrand.NewDriftingBool(0.99, 0.5, 1000)
+	for i := 0; i < 5; i++ {
+		fmt.Println(dependencyUp.Next())
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand
+
+import "math"
+
+// BytesWithEntropy generates n random bytes, whose expected Shannon entropy is bitsPerByte,
+// in range [0, 8]: 0 means a constant byte is repeated, 8 means fully random bytes.
+// It is useful to produce data with a controllable compression ratio.
+// The bytes are drawn from a set of ceil(2^bitsPerByte) random distinct symbols, one of them being
+// favored with the probability that brings the entropy to the target, the others being equally likely.
+// For example, bitsPerByte = 1 draws from a 2 symbols set, with equal probabilities.
+// It panics if bitsPerByte is not in [0, 8].
+func BytesWithEntropy(n int, bitsPerByte float64) []byte {
+	if !(bitsPerByte >= 0 && bitsPerByte <= 8) { // Note: negated condition also catches NaN.
+		panic("invalid argument to BytesWithEntropy")
+	}
+
+	var (
+		symbolsNo = int(math.Ceil(math.Exp2(bitsPerByte)))
+		symbols   = SampleIndices(256, symbolsNo)
+		pFavored  = favoredSymbolProbability(symbolsNo, bitsPerByte)
+		b         = make([]byte, n)
+	)
+	for i := range b {
+		if symbolsNo == 1 || Float64() < pFavored {
+			b[i] = byte(symbols[0])
+		} else {
+			b[i] = byte(symbols[1+globalRand.Intn(symbolsNo-1)])
+		}
+	}
+
+	return b
+}
+
+// ShuffleBytes shuffles the bytes of b in place, using the Fisher-Yates algorithm.
+// Unlike shuffling a string's runes, bytes are treated as opaque values, making it suitable
+// for fuzz corpora, where data is not meant to be valid UTF-8.
+// It does not allocate.
+func ShuffleBytes(b []byte) {
+	for i := len(b) - 1; i > 0; i-- {
+		j := globalRand.Intn(i + 1)
+		b[i], b[j] = b[j], b[i]
+	}
+}
+
+// CorruptBytes returns a copy of data, with fraction of its bytes (rounded to the nearest integer)
+// replaced by random different values, useful for fault-injection tests.
+// The corrupted positions are distinct, sampled with [SampleIndices], and each corrupted byte
+// is guaranteed to differ from the original one, picked uniformly from the other 255 values.
+// fraction is clamped to [0, 1]. The input data is not modified.
+func CorruptBytes(data []byte, fraction float64) []byte {
+	if !(fraction > 0) { // Note: negated condition also catches NaN.
+		fraction = 0
+	} else if fraction > 1 {
+		fraction = 1
+	}
+
+	corrupted := append(make([]byte, 0, len(data)), data...)
+	for _, idx := range SampleIndices(len(data), int(math.Round(fraction*float64(len(data))))) {
+		corrupted[idx] ^= byte(1 + globalRand.Intn(255)) // a non-zero xor mask always changes the byte.
+	}
+
+	return corrupted
+}
+
+// favoredSymbolProbability returns the probability p of a symbol, so that a distribution
+// of symbolsNo symbols, where that symbol has probability p and the others equally share 1-p,
+// has the given entropy (which is expected to be in [0, log2(symbolsNo)]).
+// The entropy decreases as p goes from 1/symbolsNo to 1, thus p is found with bisection.
+func favoredSymbolProbability(symbolsNo int, entropy float64) float64 {
+	if symbolsNo == 1 {
+		return 1
+	}
+
+	k := float64(symbolsNo)
+	lo, hi := 1/k, 1.0
+	for i := 0; i < 64; i++ {
+		p := (lo + hi) / 2
+		h := -p*math.Log2(p) - (1-p)*math.Log2((1-p)/(k-1))
+		if h > entropy {
+			lo = p
+		} else {
+			hi = p
+		}
+	}
+
+	return (lo + hi) / 2
+}
@@ -0,0 +1,259 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand_test
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/actforgood/xrand"
+)
+
+func TestBytesWithEntropy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("entropy is near target", testBytesWithEntropyTarget)
+	t.Run("data varies", testBytesWithEntropyVaries)
+	t.Run("panics for invalid entropy", testBytesWithEntropyPanics)
+}
+
+func testBytesWithEntropyTarget(t *testing.T) {
+	t.Parallel()
+
+	for _, testData := range [...]float64{0, 0.3, 1, 2.5, 4, 6.3, 7.9, 8} {
+		bitsPerByte := testData // capture range variable
+		t.Run(fmt.Sprintf("%.1f bits", bitsPerByte), func(t *testing.T) {
+			t.Parallel()
+
+			// act
+			result := xrand.BytesWithEntropy(200000, bitsPerByte)
+
+			// assert
+			assertEqual(t, 200000, len(result))
+			entropy := empiricalEntropy(result)
+			if math.Abs(entropy-bitsPerByte) > 0.05 {
+				t.Errorf("expected entropy ~%.2f bits per byte, but got %.3f", bitsPerByte, entropy)
+			}
+		})
+	}
+}
+
+func testBytesWithEntropyVaries(t *testing.T) {
+	t.Parallel()
+
+	// act
+	result1 := xrand.BytesWithEntropy(64, 3)
+	result2 := xrand.BytesWithEntropy(64, 3)
+
+	// assert
+	assertTrue(t, !bytes.Equal(result1, result2))
+	assertEqual(t, 0, len(xrand.BytesWithEntropy(0, 3)))
+}
+
+func testBytesWithEntropyPanics(t *testing.T) {
+	t.Parallel()
+
+	// act & assert
+	assertPanics(t, func() { _ = xrand.BytesWithEntropy(10, -0.1) })
+	assertPanics(t, func() { _ = xrand.BytesWithEntropy(10, 8.1) })
+	assertPanics(t, func() { _ = xrand.BytesWithEntropy(10, math.NaN()) })
+}
+
+func TestShuffleBytes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("result is a permutation", testShuffleBytesIsPermutation)
+	t.Run("distribution is flat", testShuffleBytesIsUniform)
+	t.Run("empty and single byte", testShuffleBytesNoop)
+}
+
+func testShuffleBytesIsPermutation(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		input        = []byte{0x00, 0xFF, 0xC3, 0x28, 0xC3, 0x28, 'a', 'b', 0x80, 0x00} // not valid UTF-8.
+		orderings    = make(map[string]struct{})
+		originalData = append([]byte(nil), input...)
+	)
+
+	for i := 0; i < 100; i++ {
+		subject := append([]byte(nil), input...)
+
+		// act
+		xrand.ShuffleBytes(subject)
+
+		// assert
+		assertSamePermutation(t, originalData, subject)
+		orderings[string(subject)] = struct{}{}
+	}
+	assertTrue(t, len(orderings) > 1)
+}
+
+func testShuffleBytesIsUniform(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 60000
+	var (
+		permutations = map[string]int{"abc": 0, "acb": 1, "bac": 2, "bca": 3, "cab": 4, "cba": 5}
+		counts       = make([]int, len(permutations))
+	)
+
+	for i := 0; i < iterations; i++ {
+		subject := []byte("abc")
+
+		// act
+		xrand.ShuffleBytes(subject)
+
+		// assert
+		idx, found := permutations[string(subject)]
+		if assertTrue(t, found) {
+			counts[idx]++
+		}
+	}
+	assertUniform(t, counts, iterations, 0.05)
+}
+
+func testShuffleBytesNoop(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		empty  []byte
+		single = []byte{0xFE}
+	)
+
+	// act
+	xrand.ShuffleBytes(empty)
+	xrand.ShuffleBytes(single)
+
+	// assert
+	assertEqual(t, 0, len(empty))
+	assertTrue(t, bytes.Equal([]byte{0xFE}, single))
+}
+
+func TestCorruptBytes(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		data  = xrand.BytesWithEntropy(1000, 8)
+		tests = [...]struct {
+			name          string
+			inputFraction float64
+			expectedDiffs int
+		}{
+			{name: "10%", inputFraction: 0.1, expectedDiffs: 100},
+			{name: "33.33%", inputFraction: 1. / 3, expectedDiffs: 333},
+			{name: "all", inputFraction: 1, expectedDiffs: 1000},
+			{name: "none", inputFraction: 0, expectedDiffs: 0},
+			{name: "clamped above 1", inputFraction: 2.5, expectedDiffs: 1000},
+			{name: "clamped below 0", inputFraction: -0.5, expectedDiffs: 0},
+			{name: "NaN", inputFraction: math.NaN(), expectedDiffs: 0},
+		}
+	)
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			original := append([]byte(nil), data...)
+
+			// act
+			result := xrand.CorruptBytes(data, test.inputFraction)
+
+			// assert
+			assertTrue(t, bytes.Equal(original, data)) // input is not modified
+			assertEqual(t, len(data), len(result))
+			diffs := 0
+			for i := range result {
+				if result[i] != data[i] {
+					diffs++
+				}
+			}
+			assertEqual(t, test.expectedDiffs, diffs)
+			if len(result) > 0 {
+				result[0]++ // returned slice does not share memory with input
+				assertTrue(t, bytes.Equal(original, data))
+			}
+		})
+	}
+
+	t.Run("corrupted positions vary", func(t *testing.T) {
+		t.Parallel()
+
+		// act
+		result1 := xrand.CorruptBytes(data, 0.05)
+		result2 := xrand.CorruptBytes(data, 0.05)
+
+		// assert
+		assertTrue(t, !bytes.Equal(result1, result2))
+	})
+
+	t.Run("empty data", func(t *testing.T) {
+		t.Parallel()
+
+		assertEqual(t, 0, len(xrand.CorruptBytes(nil, 0.5)))
+	})
+}
+
+func BenchmarkShuffleBytes(b *testing.B) {
+	data := xrand.BytesWithEntropy(1024, 8)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		xrand.ShuffleBytes(data)
+	}
+}
+
+// empiricalEntropy returns the Shannon entropy (in bits per byte)
+// of the bytes frequencies in data.
+func empiricalEntropy(data []byte) float64 {
+	var counts [256]int
+	for _, b := range data {
+		counts[b]++
+	}
+	var entropy float64
+	for _, count := range counts {
+		if count > 0 {
+			p := float64(count) / float64(len(data))
+			entropy -= p * math.Log2(p)
+		}
+	}
+
+	return entropy
+}
+
+func ExampleBytesWithEntropy() {
+	// generate 1KiB of data, only half as random as random data can be.
+	data := xrand.BytesWithEntropy(1024, 4)
+	fmt.Println(len(data))
+
+	// Output:
+	// 1024
+}
+
+func ExampleShuffleBytes() {
+	// mutate a fuzz corpus entry, reordering its bytes.
+	entry := []byte{0xC3, 0x28, 0x00, 0xFF}
+	xrand.ShuffleBytes(entry)
+	fmt.Printf("% x\n", entry)
+}
+
+func ExampleCorruptBytes() {
+	// corrupt 10% of a payload (3 bytes out of 29), to test the checksum verification.
+	payload := []byte("some payload, with a checksum")
+	corrupted := xrand.CorruptBytes(payload, 0.1)
+	fmt.Println(bytes.Equal(payload, corrupted))
+
+	// Output:
+	// false
+}
@@ -0,0 +1,40 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand
+
+// RollAdvantage rolls a die with given no. of sides twice, and returns the higher result,
+// in range [1, sides].
+// It panics if sides <= 0.
+func RollAdvantage(sides int) int {
+	first, second := roll(sides, "RollAdvantage"), roll(sides, "RollAdvantage")
+	if first > second {
+		return first
+	}
+
+	return second
+}
+
+// RollDisadvantage rolls a die with given no. of sides twice, and returns the lower result,
+// in range [1, sides].
+// It panics if sides <= 0.
+func RollDisadvantage(sides int) int {
+	first, second := roll(sides, "RollDisadvantage"), roll(sides, "RollDisadvantage")
+	if first < second {
+		return first
+	}
+
+	return second
+}
+
+// roll rolls a die with given no. of sides, returning a result in range [1, sides].
+// It panics if sides <= 0, mentioning the caller in the message.
+func roll(sides int, caller string) int {
+	if sides <= 0 {
+		panic("invalid argument to " + caller)
+	}
+
+	return Intn(sides) + 1
+}
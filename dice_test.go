@@ -0,0 +1,86 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/actforgood/xrand"
+)
+
+func TestRollAdvantage(t *testing.T) {
+	t.Parallel()
+
+	testRoll(t, xrand.RollAdvantage, 1)
+}
+
+func TestRollDisadvantage(t *testing.T) {
+	t.Parallel()
+
+	testRoll(t, xrand.RollDisadvantage, -1)
+}
+
+// testRoll tests a two dice roll; skew is 1 if the mean should be higher than a single roll's one,
+// -1 if it should be lower.
+func testRoll(t *testing.T, subject func(int) int, skew float64) {
+	t.Helper()
+
+	t.Run("within bounds", func(t *testing.T) {
+		t.Parallel()
+
+		for _, sides := range [...]int{1, 2, 6, 20} {
+			seen := make(map[int]struct{}, sides)
+			for i := 0; i < 20000; i++ { // extreme values have 1/sides^2 probability
+				// act
+				result := subject(sides)
+
+				// assert
+				assertTrue(t, result >= 1 && result <= sides)
+				seen[result] = struct{}{}
+			}
+			assertEqual(t, sides, len(seen))
+		}
+	})
+
+	t.Run("skews the mean", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		const (
+			sides      = 20
+			iterations = 100000
+			singleMean = float64(sides+1) / 2 // 10.5
+		)
+		sum := 0
+
+		// act
+		for i := 0; i < iterations; i++ {
+			sum += subject(sides)
+		}
+
+		// assert
+		mean := float64(sum) / iterations // ~13.825 for advantage, ~7.175 for disadvantage
+		assertTrue(t, skew*(mean-singleMean) > 3)
+	})
+
+	t.Run("panics for non-positive sides", func(t *testing.T) {
+		t.Parallel()
+
+		assertPanics(t, func() { _ = subject(0) })
+		assertPanics(t, func() { _ = subject(-6) })
+	})
+}
+
+func ExampleRollAdvantage() {
+	attack := xrand.RollAdvantage(20)
+	fmt.Println("attack roll with advantage:", attack)
+}
+
+func ExampleRollDisadvantage() {
+	attack := xrand.RollDisadvantage(20)
+	fmt.Println("attack roll with disadvantage:", attack)
+}
@@ -0,0 +1,125 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand
+
+import (
+	"io"
+	"time"
+)
+
+// MaxPickValidatedAttempts is the max no. of random picks PickValidated tries, exported for testing purposes.
+const MaxPickValidatedAttempts = maxPickValidatedAttempts
+
+// SetCryptoReader replaces the source of cryptographically secure random bytes, for testing purposes.
+// Returned function restores the original source.
+// Tests calling it should not run in parallel.
+func SetCryptoReader(r io.Reader) (restore func()) {
+	original := cryptoReader
+	cryptoReader = r
+
+	return func() {
+		cryptoReader = original
+	}
+}
+
+// ReseedGlobalSource seeds again the global source, for testing purposes.
+func ReseedGlobalSource() {
+	seedGlobalSource()
+}
+
+// StringRejection generates a random string like [String], but always through the rejection sampling path,
+// for benchmarking purposes.
+func StringRejection(n int, alphabet ...string) string {
+	b := make([]byte, n)
+	fillStringRejection(b, alphabetOrDefault(alphabet))
+
+	return string(b)
+}
+
+// TimeZoneNames returns the time zone names TimeZone picks from, for testing purposes.
+func TimeZoneNames() []string {
+	return append([]string(nil), timeZoneNames[:]...)
+}
+
+// LatLngInBoxFrom returns the coordinate [LatLngInBox] maps given random draws in [0.0,1.0) to, for testing purposes.
+func LatLngInBoxFrom(minLat, minLng, maxLat, maxLng, latRand, lngRand float64) (lat, lng float64) {
+	return latLngInBox(minLat, minLng, maxLat, maxLng, latRand, lngRand)
+}
+
+// SetLoadLocation replaces the time zone loader, for testing purposes.
+// Returned function restores the original loader.
+// Tests calling it should not run in parallel.
+func SetLoadLocation(load func(name string) (*time.Location, error)) (restore func()) {
+	original := loadLocation
+	loadLocation = load
+
+	return func() {
+		loadLocation = original
+	}
+}
+
+// SetNow replaces the clock, for testing purposes.
+// Returned function restores the original clock.
+// Tests calling it should not run in parallel.
+func SetNow(clock func() time.Time) (restore func()) {
+	original := now
+	now = clock
+
+	return func() {
+		now = original
+	}
+}
+
+// SlugWords returns the embedded adjectives and nouns Slug picks from, for testing purposes.
+func SlugWords() (adjectives, nouns []string) {
+	return append([]string(nil), slugAdjectives[:]...), append([]string(nil), slugNouns[:]...)
+}
+
+// SetSleep replaces the function used to sleep, for testing purposes.
+// Returned function restores the original one.
+// Tests calling it should not run in parallel.
+func SetSleep(sleep func(d time.Duration)) (restore func()) {
+	original := timeSleep
+	timeSleep = sleep
+
+	return func() {
+		timeSleep = original
+	}
+}
+
+// SecureStringPerChar generates a cryptographically secure random string like [SecureString],
+// but reading from crypto/rand a byte for every draw, for benchmarking purposes.
+func SecureStringPerChar(n int, alphabet ...string) (string, error) {
+	var (
+		a    = alphabetOrDefault(alphabet)
+		mask = byte(1<<countBits(len(a)) - 1)
+		b    = make([]byte, n)
+		r    [1]byte
+	)
+	for i := 0; i < n; {
+		if err := readCryptoRand(r[:]); err != nil {
+			return "", err
+		}
+		if alphabetIdx := int(r[0] & mask); alphabetIdx < len(a) {
+			b[i] = a[alphabetIdx]
+			i++
+		}
+	}
+
+	return string(b), nil
+}
+
+// SetJitterFloat64 replaces the source of random floats the jitter is computed from, for testing purposes.
+// Returned function restores the original source.
+// Tests calling it should not run in parallel.
+func SetJitterFloat64(float64Fn func() float64) (restore func()) {
+	original := jitterFloat64
+	jitterFloat64 = float64Fn
+
+	return func() {
+		jitterFloat64 = original
+	}
+}
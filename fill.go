@@ -0,0 +1,117 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+var (
+	// ErrNotStructPointer is returned when a non-nil pointer to a struct is expected, but something else is passed.
+	ErrNotStructPointer = errors.New("xrand: expected a non-nil pointer to a struct")
+	// ErrUnsupportedKind is returned when a struct field's kind cannot be filled with a random value.
+	ErrUnsupportedKind = errors.New("xrand: unsupported field kind")
+)
+
+const (
+	// fillStringLength is the length of the random strings [FillStruct] sets.
+	fillStringLength = 16
+	// fillDuration is the duration [FillStruct] alters with [Jitter], for time.Duration fields.
+	fillDuration = time.Second
+)
+
+// durationType is the reflected type of time.Duration.
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// FillStruct sets the exported fields of the struct ptr points to, to random values
+// based on their kind, for quickly populating structs in tests:
+//   - signed integers to a random non-negative value fitting the type, like with [Intn];
+//   - unsigned integers to a random value fitting the type;
+//   - floats to a random value in [0.0, 1.0), like with [Float64];
+//   - strings to a random alphanumeric string of 16 characters, like with [String];
+//   - bools to a random bool;
+//   - time.Duration fields to a second, altered with [Jitter];
+//   - nested structs are filled recursively.
+//
+// Unexported fields are left unchanged.
+// It returns [ErrNotStructPointer] if ptr is not a non-nil pointer to a struct,
+// [ErrUnsupportedKind] (wrapped, with the field's name) if an exported field has another kind,
+// like a slice, map, or pointer. Fields are checked before any of them is set,
+// so on error the struct is left unchanged.
+func FillStruct(ptr any) error {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return ErrNotStructPointer
+	}
+
+	s := v.Elem()
+	if err := checkFillable(s.Type(), ""); err != nil {
+		return err
+	}
+	fillStruct(s)
+
+	return nil
+}
+
+// checkFillable returns an error if the struct type t has an exported field which cannot be filled.
+// Path is the name of the struct field, for nested structs.
+func checkFillable(t reflect.Type, path string) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := path + field.Name
+		switch field.Type.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+			reflect.Float32, reflect.Float64, reflect.String, reflect.Bool:
+		case reflect.Struct:
+			if err := checkFillable(field.Type, name+"."); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%w: field %s of kind %s", ErrUnsupportedKind, name, field.Type.Kind())
+		}
+	}
+
+	return nil
+}
+
+// fillStruct sets the exported fields of the struct s to random values.
+// Fields are expected to be fillable, see checkFillable.
+func fillStruct(s reflect.Value) {
+	t := s.Type()
+	for i := 0; i < s.NumField(); i++ {
+		if !t.Field(i).IsExported() {
+			continue
+		}
+		field := s.Field(i)
+		switch field.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if field.Type() == durationType {
+				field.SetInt(int64(Jitter(fillDuration)))
+			} else { // Note: keep as many random bits as the type holds, sign bit excluded.
+				field.SetInt(globalRand.Int63() >> (64 - field.Type().Bits()))
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			field.SetUint(globalRand.Uint64() >> (64 - field.Type().Bits()))
+		case reflect.Float32:
+			field.SetFloat(float64(globalRand.Float32())) // Note: a float64 could round to 1.0 as float32.
+		case reflect.Float64:
+			field.SetFloat(Float64())
+		case reflect.String:
+			field.SetString(String(fillStringLength))
+		case reflect.Bool:
+			field.SetBool(globalRand.Int63()&1 == 1)
+		case reflect.Struct:
+			fillStruct(field)
+		}
+	}
+}
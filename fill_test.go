@@ -0,0 +1,155 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/actforgood/xrand"
+)
+
+// fillTestAddress is a struct nested in fillTestUser.
+type fillTestAddress struct {
+	City string
+	Zip  uint16
+}
+
+// fillTestUser is a struct with mixed fields, used in FillStruct tests.
+type fillTestUser struct {
+	ID       int64
+	Age      int8
+	Name     string
+	Active   bool
+	Score    float64
+	Ratio    float32
+	Visits   uint
+	Timeout  time.Duration
+	Address  fillTestAddress
+	internal string
+}
+
+func TestFillStruct(t *testing.T) {
+	t.Parallel()
+
+	t.Run("supported fields are populated", testFillStructPopulates)
+	t.Run("error for unsupported kinds", testFillStructUnsupported)
+	t.Run("error for non struct pointers", testFillStructNotStructPointer)
+}
+
+func testFillStructPopulates(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		alphanumReg = regexp.MustCompile(`^[a-z0-9]{16}$`)
+		actives     = make(map[bool]struct{}, 2)
+	)
+
+	for i := 0; i < 100; i++ {
+		subject := fillTestUser{internal: "unchanged"}
+
+		// act
+		err := xrand.FillStruct(&subject)
+
+		// assert
+		assertNil(t, err)
+		assertTrue(t, subject.ID > 0)
+		assertTrue(t, subject.Age >= 0)
+		assertTrue(t, alphanumReg.MatchString(subject.Name))
+		assertTrue(t, subject.Score >= 0 && subject.Score < 1)
+		assertTrue(t, subject.Ratio >= 0 && subject.Ratio < 1)
+		assertTrue(t, subject.Timeout >= 800*time.Millisecond && subject.Timeout < 1200*time.Millisecond)
+		assertTrue(t, alphanumReg.MatchString(subject.Address.City))
+		assertEqual(t, "unchanged", subject.internal)
+		actives[subject.Active] = struct{}{}
+	}
+	assertEqual(t, 2, len(actives))
+
+	// values vary across calls
+	var subject1, subject2 fillTestUser
+	assertNil(t, xrand.FillStruct(&subject1))
+	assertNil(t, xrand.FillStruct(&subject2))
+	assertTrue(t, !reflect.DeepEqual(subject1, subject2))
+}
+
+func testFillStructUnsupported(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	type withSlice struct {
+		Name string
+		Tags []string
+	}
+	type withNestedMap struct {
+		Nested struct {
+			Labels map[string]string
+		}
+	}
+	type withPointer struct {
+		Next *withPointer
+	}
+	tests := [...]struct {
+		name          string
+		input         any
+		expectedField string
+	}{
+		{name: "slice", input: &withSlice{Name: "unchanged"}, expectedField: "Tags"},
+		{name: "nested map", input: &withNestedMap{}, expectedField: "Nested.Labels"},
+		{name: "pointer", input: &withPointer{}, expectedField: "Next"},
+	}
+
+	for _, test := range tests {
+		// act
+		err := xrand.FillStruct(test.input)
+
+		// assert
+		assertTrue(t, errors.Is(err, xrand.ErrUnsupportedKind))
+		assertTrue(t, strings.Contains(err.Error(), "field "+test.expectedField+" "))
+	}
+	assertEqual(t, "unchanged", tests[0].input.(*withSlice).Name) // left unchanged on error
+}
+
+func testFillStructNotStructPointer(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		nilUser *fillTestUser
+		number  = 5
+		inputs  = [...]any{nil, fillTestUser{}, nilUser, &number, "string"}
+	)
+
+	for _, input := range inputs {
+		// act
+		err := xrand.FillStruct(input)
+
+		// assert
+		assertTrue(t, errors.Is(err, xrand.ErrNotStructPointer))
+	}
+}
+
+func ExampleFillStruct() {
+	// quickly populate a struct for a test.
+	type order struct {
+		ID       int64
+		Customer string
+		Total    float64
+		Paid     bool
+	}
+	var o order
+	if err := xrand.FillStruct(&o); err != nil {
+		fmt.Println(err)
+
+		return
+	}
+	fmt.Printf("%+v\n", o) // like {ID:5577006791947779410 Customer:x3k9qa... Total:0.94 Paid:true}
+}
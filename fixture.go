@@ -0,0 +1,273 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+// LatLng generates a random valid geographic coordinate, with latitude in [-90,90)
+// and longitude in [-180,180), uniformly distributed in degrees.
+func LatLng() (lat, lng float64) {
+	return LatLngInBox(-90, -180, 90, 180)
+}
+
+// LatLngInBox generates a random geographic coordinate within the bounding box, with
+// latitude in [minLat,maxLat) and longitude in [minLng,maxLng), uniformly distributed in degrees.
+// If minLng > maxLng the box is considered to cross the antimeridian (180th meridian),
+// and longitude is in [minLng,180) or [-180,maxLng).
+// As 180 and -180 are the same meridian, a minLng of 180 is treated as -180, and a maxLng of -180 as 180.
+// It panics if latitudes are not in [-90,90], longitudes are not in [-180,180],
+// minLat >= maxLat or minLng == maxLng (including minLng = 180 and maxLng = -180).
+func LatLngInBox(minLat, minLng, maxLat, maxLng float64) (lat, lng float64) {
+	if minLat < -90 || maxLat > 90 || minLat >= maxLat ||
+		minLng < -180 || minLng > 180 || maxLng < -180 || maxLng > 180 || minLng == maxLng ||
+		(minLng == 180 && maxLng == -180) {
+		panic("invalid argument to LatLngInBox")
+	}
+
+	return latLngInBox(minLat, minLng, maxLat, maxLng, Float64(), Float64())
+}
+
+// latLngInBox maps the random draws latRand, lngRand in [0.0,1.0) to a coordinate
+// within the (already validated) bounding box.
+func latLngInBox(minLat, minLng, maxLat, maxLng, latRand, lngRand float64) (lat, lng float64) {
+	// Note: the same meridian bounds are normalized, otherwise they are taken as crossing the antimeridian.
+	if minLng == 180 {
+		minLng = -180
+	}
+	if maxLng == -180 {
+		maxLng = 180
+	}
+
+	lat = minLat + latRand*(maxLat-minLat)
+	if lat >= maxLat { // float rounding errors.
+		lat = math.Nextafter(maxLat, minLat)
+	}
+
+	if minLng < maxLng {
+		lng = minLng + lngRand*(maxLng-minLng)
+		if lng >= maxLng { // float rounding errors.
+			lng = math.Nextafter(maxLng, minLng)
+		}
+
+		return lat, lng
+	}
+
+	// crosses the antimeridian
+	lng = minLng + lngRand*(maxLng-minLng+360)
+	if lng >= 180 {
+		lng -= 360
+		if lng >= maxLng { // float rounding errors.
+			lng = math.Nextafter(maxLng, -180)
+		}
+	}
+
+	return lat, lng
+}
+
+// defaultEmailDomain is the domain [Email] uses when none is provided.
+const defaultEmailDomain = "example.com"
+
+// Email generates a random email-like string, "localpart@domain", useful for fixtures.
+// The local part consists of 8 to 16 random lowercase letters and digits,
+// and the domain is randomly picked from provided domains, defaulting to "example.com"
+// (a domain reserved for documentation, RFC 2606) if none is provided.
+func Email(domains ...string) string {
+	domain := defaultEmailDomain
+	if len(domains) > 0 {
+		domain = domains[globalRand.Intn(len(domains))]
+	}
+
+	return String(IntnBetween(8, 17), AlphanumAlphabet) + "@" + domain
+}
+
+// PhoneNumber generates a random phone number like string, from the format, where each '#'
+// is replaced by a random digit and any other character is kept as is.
+// For example, format "+1-###-###-####" can produce "+1-555-012-3456".
+// It is locale agnostic, the format being the caller's choice.
+func PhoneNumber(format string) string {
+	var (
+		b      = []byte(format)
+		digits = make([]byte, strings.Count(format, "#"))
+	)
+	fillString(digits, DigitsAlphabet)
+	for i := range b {
+		if b[i] == '#' {
+			b[i], digits = digits[0], digits[1:]
+		}
+	}
+
+	return *(*string)(unsafe.Pointer(&b))
+}
+
+// timeZoneNames are the IANA time zone names [TimeZone] picks from.
+var timeZoneNames = [...]string{
+	"UTC",
+	"Africa/Cairo", "Africa/Johannesburg", "Africa/Lagos", "Africa/Nairobi",
+	"America/Anchorage", "America/Argentina/Buenos_Aires", "America/Bogota", "America/Chicago",
+	"America/Denver", "America/Halifax", "America/Los_Angeles", "America/Mexico_City",
+	"America/New_York", "America/Sao_Paulo", "America/St_Johns", "America/Toronto",
+	"Asia/Bangkok", "Asia/Dubai", "Asia/Hong_Kong", "Asia/Jakarta", "Asia/Jerusalem",
+	"Asia/Karachi", "Asia/Kathmandu", "Asia/Kolkata", "Asia/Seoul", "Asia/Shanghai",
+	"Asia/Singapore", "Asia/Tehran", "Asia/Tokyo",
+	"Atlantic/Reykjavik",
+	"Australia/Adelaide", "Australia/Brisbane", "Australia/Perth", "Australia/Sydney",
+	"Europe/Amsterdam", "Europe/Athens", "Europe/Berlin", "Europe/Bucharest", "Europe/Istanbul",
+	"Europe/Lisbon", "Europe/London", "Europe/Madrid", "Europe/Moscow", "Europe/Paris",
+	"Europe/Rome", "Europe/Warsaw",
+	"Pacific/Auckland", "Pacific/Chatham", "Pacific/Honolulu", "Pacific/Kiritimati",
+}
+
+// loadLocation loads a time zone by its IANA name.
+var loadLocation = time.LoadLocation
+
+// TimeZone returns a random time zone, loaded with [time.LoadLocation], picked from a curated list
+// of common IANA zone names, covering all continents, and offsets with unusual minutes
+// (like Asia/Kathmandu, +05:45), useful for locale-aware fixtures.
+// If a zone cannot be loaded (the time zone database is incomplete on the system, for example),
+// another zone is tried, falling back on [time.UTC] if none can be loaded.
+// Importing the [time/tzdata] package makes the zones available on systems without a time zone database.
+func TimeZone() *time.Location {
+	for _, idx := range globalRand.Perm(len(timeZoneNames)) {
+		if loc, err := loadLocation(timeZoneNames[idx]); err == nil {
+			return loc
+		}
+	}
+
+	return time.UTC
+}
+
+// LuhnNumber generates a random numeric string of given length, whose last digit is the Luhn checksum
+// of the others, like payment card numbers, useful for payment-flow fixtures.
+// The first digit is never 0, so the number does not lose digits if parsed as an integer.
+// Note: these are synthetic numbers, passing the Luhn validation only; they are not real card numbers,
+// and are not meant to have a valid issuer identification number.
+// It panics if length < 2, as at least a digit besides the checksum is needed.
+func LuhnNumber(length int) string {
+	if length < 2 {
+		panic("invalid argument to LuhnNumber")
+	}
+
+	b := make([]byte, length)
+	b[0] = byte('1' + globalRand.Intn(9))
+	fillString(b[1:length-1], DigitsAlphabet)
+
+	// starting from the rightmost payload digit, every other digit is doubled.
+	sum := 0
+	for i := length - 2; i >= 0; i-- {
+		digit := int(b[i] - '0')
+		if (length-2-i)%2 == 0 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+	b[length-1] = byte('0' + (10-sum%10)%10)
+
+	return *(*string)(unsafe.Pointer(&b))
+}
+
+// ISBN13 generates a random 13 digits ISBN (International Standard Book Number), prefixed by 978 or 979,
+// whose last digit is the ISBN-13 check digit, useful for library / catalog fixtures.
+// Note: these are synthetic numbers, with a valid check digit only; they are not meant to have
+// a registered group / publisher.
+func ISBN13() string {
+	b := make([]byte, 13)
+	copy(b, "978")
+	if globalRand.Int63()&1 == 1 {
+		b[2] = '9'
+	}
+	fillString(b[3:12], DigitsAlphabet)
+
+	// digits are weighted alternately by 1 and 3, from left to right.
+	sum := 0
+	for i := 0; i < 12; i++ {
+		digit := int(b[i] - '0')
+		if i%2 == 1 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	b[12] = byte('0' + (10-sum%10)%10)
+
+	return *(*string)(unsafe.Pointer(&b))
+}
+
+// semVerPreReleases are the labels [SemVerPreRelease] picks from.
+var semVerPreReleases = [...]string{"alpha", "beta", "rc"}
+
+// SemVer generates a random semantic version (https://semver.org) "MAJOR.MINOR.PATCH",
+// useful for fixtures involving version comparisons.
+// MAJOR is in [0,10), MINOR is in [0,20) and PATCH is in [0,50).
+func SemVer() string {
+	b := make([]byte, 0, 8)
+	b = strconv.AppendInt(b, int64(globalRand.Intn(10)), 10)
+	b = append(b, '.')
+	b = strconv.AppendInt(b, int64(globalRand.Intn(20)), 10)
+	b = append(b, '.')
+	b = strconv.AppendInt(b, int64(globalRand.Intn(50)), 10)
+
+	return string(b)
+}
+
+// SemVerPreRelease generates a random semantic version (https://semver.org) with a pre-release suffix,
+// "MAJOR.MINOR.PATCH-LABEL.N", where LABEL is one of "alpha", "beta", "rc" and N is in [1,10).
+// Version core is generated like in [SemVer].
+func SemVerPreRelease() string {
+	label := semVerPreReleases[globalRand.Intn(len(semVerPreReleases))]
+
+	return SemVer() + "-" + label + "." + strconv.Itoa(IntnBetween(1, 10))
+}
+
+var (
+	// defaultHTTPMethods are the HTTP methods [HTTPMethod] picks from, by default.
+	defaultHTTPMethods = [...]string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+	// defaultHTTPMethodWeights are the weights of defaultHTTPMethods, a GET-heavy distribution.
+	defaultHTTPMethodWeights = [...]float64{60, 20, 8, 5, 5, 1, 1}
+	// httpMethods are the valid HTTP methods, as defined in RFC 9110 and RFC 5789 (PATCH).
+	httpMethods = map[string]struct{}{
+		"GET": {}, "HEAD": {}, "POST": {}, "PUT": {}, "PATCH": {},
+		"DELETE": {}, "CONNECT": {}, "OPTIONS": {}, "TRACE": {},
+	}
+)
+
+// HTTPMethod returns a random HTTP method (verb), picked with a probability proportional to its weight,
+// useful for load tests, like map[string]float64{"GET": 8, "POST": 2}.
+// If weights is empty, a GET-heavy distribution is used: GET 60%, POST 20%, PUT 8%, PATCH 5%,
+// DELETE 5%, HEAD 1%, OPTIONS 1%.
+// It panics if a method is not a valid (uppercase) HTTP method, a weight is negative
+// (or NaN / infinite), or all weights are zero.
+func HTTPMethod(weights map[string]float64) string {
+	if len(weights) == 0 {
+		return defaultHTTPMethods[pickWeightedIndex(defaultHTTPMethodWeights[:], 100)]
+	}
+
+	methods := make([]string, 0, len(weights))
+	for method := range weights {
+		if _, valid := httpMethods[method]; !valid {
+			panic("invalid argument to HTTPMethod")
+		}
+		methods = append(methods, method)
+	}
+
+	methodWeights := make([]float64, len(methods))
+	for idx, method := range methods {
+		methodWeights[idx] = weights[method]
+	}
+	total, err := totalWeight(methodWeights)
+	if err != nil {
+		panic("invalid argument to HTTPMethod")
+	}
+
+	return methods[pickWeightedIndex(methodWeights, total)]
+}
@@ -0,0 +1,638 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/actforgood/xrand"
+)
+
+func TestLatLng(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10000; i++ {
+		// act
+		lat, lng := xrand.LatLng()
+
+		// assert
+		assertTrue(t, lat >= -90 && lat < 90)
+		assertTrue(t, lng >= -180 && lng < 180)
+	}
+}
+
+func TestLatLngInBox(t *testing.T) {
+	t.Parallel()
+
+	t.Run("coordinates are within the box", testLatLngInBoxWithinBox)
+	t.Run("box crossing the antimeridian", testLatLngInBoxAntimeridian)
+	t.Run("extreme draws stay within the box", testLatLngInBoxExtremeDraws)
+	t.Run("panics for invalid bounds", testLatLngInBoxPanics)
+}
+
+func testLatLngInBoxWithinBox(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject = xrand.LatLngInBox
+		tests   = [...]struct {
+			name                           string
+			minLat, minLng, maxLat, maxLng float64
+		}{
+			{name: "Romania", minLat: 43.6, minLng: 20.2, maxLat: 48.3, maxLng: 29.7},
+			{name: "crossing the equator", minLat: -10, minLng: 30, maxLat: 10, maxLng: 40},
+			{name: "crossing the prime meridian", minLat: 50, minLng: -5, maxLat: 55, maxLng: 5},
+			{name: "crossing both", minLat: -1, minLng: -1, maxLat: 1, maxLng: 1},
+			{name: "southern hemisphere", minLat: -45, minLng: -75, maxLat: -20, maxLng: -50},
+		}
+	)
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			for i := 0; i < 1000; i++ {
+				// act
+				lat, lng := subject(test.minLat, test.minLng, test.maxLat, test.maxLng)
+
+				// assert
+				assertTrue(t, lat >= test.minLat && lat < test.maxLat)
+				assertTrue(t, lng >= test.minLng && lng < test.maxLng)
+			}
+		})
+	}
+}
+
+func testLatLngInBoxAntimeridian(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject                  = xrand.LatLngInBox
+		seenEastern, seenWestern bool
+	)
+
+	for i := 0; i < 1000; i++ {
+		// act
+		lat, lng := subject(-20, 170, -10, -170) // Fiji area
+
+		// assert
+		assertTrue(t, lat >= -20 && lat < -10)
+		assertTrue(t, (lng >= 170 && lng < 180) || (lng >= -180 && lng < -170))
+		if lng >= 170 {
+			seenEastern = true
+		} else {
+			seenWestern = true
+		}
+	}
+	assertTrue(t, seenEastern)
+	assertTrue(t, seenWestern)
+
+	for i := 0; i < 1000; i++ {
+		// act
+		_, lng := subject(-20, 180, -10, -170) // starting at the antimeridian
+
+		// assert
+		assertTrue(t, lng >= -180 && lng < -170)
+	}
+}
+
+func testLatLngInBoxExtremeDraws(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject = xrand.LatLngInBoxFrom
+		maxDraw = math.Nextafter(1, 0) // the largest value Float64 returns.
+		tests   = [...]struct {
+			name                           string
+			minLat, minLng, maxLat, maxLng float64
+		}{
+			{name: "rounding up to the max", minLat: 33.3, minLng: 45, maxLat: 90, maxLng: 60.3},
+			{name: "narrow box", minLat: 1, minLng: 1, maxLat: 1.1, maxLng: 1.1},
+			{name: "whole globe", minLat: -90, minLng: -180, maxLat: 90, maxLng: 180},
+			{name: "crossing the antimeridian", minLat: -20, minLng: 170, maxLat: -10, maxLng: -170},
+			{name: "ending at the antimeridian", minLat: 0, minLng: 170, maxLat: 10, maxLng: -180},
+			{name: "starting at the antimeridian", minLat: 0, minLng: 180, maxLat: 10, maxLng: -170},
+		}
+	)
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			for _, draw := range [...]float64{0, maxDraw} {
+				// act
+				lat, lng := subject(test.minLat, test.minLng, test.maxLat, test.maxLng, draw, draw)
+
+				// assert
+				assertTrue(t, lat >= test.minLat && lat < test.maxLat)
+				assertTrue(t, lng >= -180 && lng < 180)
+				switch {
+				case test.minLng == 180: // treated as -180.
+					assertTrue(t, lng < test.maxLng)
+				case test.minLng < test.maxLng:
+					assertTrue(t, lng >= test.minLng && lng < test.maxLng)
+				default:
+					assertTrue(t, lng >= test.minLng || lng < test.maxLng)
+				}
+			}
+		})
+	}
+}
+
+func testLatLngInBoxPanics(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject = xrand.LatLngInBox
+		tests   = [...]struct {
+			name                           string
+			minLat, minLng, maxLat, maxLng float64
+		}{
+			{name: "min lat too low", minLat: -91, minLng: 0, maxLat: 10, maxLng: 10},
+			{name: "max lat too high", minLat: 0, minLng: 0, maxLat: 91, maxLng: 10},
+			{name: "min lat >= max lat", minLat: 10, minLng: 0, maxLat: 10, maxLng: 10},
+			{name: "min lng too low", minLat: 0, minLng: -181, maxLat: 10, maxLng: 10},
+			{name: "max lng too high", minLat: 0, minLng: 0, maxLat: 10, maxLng: 181},
+			{name: "min lng = max lng", minLat: 0, minLng: 5, maxLat: 10, maxLng: 5},
+			{name: "min lng = 180, max lng = -180", minLat: 0, minLng: 180, maxLat: 10, maxLng: -180},
+		}
+	)
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			// act & assert
+			assertPanics(t, func() {
+				_, _ = subject(test.minLat, test.minLng, test.maxLat, test.maxLng)
+			})
+		})
+	}
+}
+
+func TestEmail(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default domain", testEmailDefaultDomain)
+	t.Run("provided domains", testEmailProvidedDomains)
+}
+
+func testEmailDefaultDomain(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		emailReg   = regexp.MustCompile(`^[a-z0-9]{8,16}@example\.com$`)
+		localParts = make(map[string]struct{})
+	)
+
+	for i := 0; i < 1000; i++ {
+		// act
+		result := xrand.Email()
+
+		// assert
+		assertTrue(t, emailReg.MatchString(result))
+		localParts[strings.Split(result, "@")[0]] = struct{}{}
+	}
+	assertTrue(t, len(localParts) > 990)
+}
+
+func testEmailProvidedDomains(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		emailReg = regexp.MustCompile(`^[a-z0-9]{8,16}@(test\.org|mail\.test)$`)
+		domains  = make(map[string]int)
+	)
+
+	for i := 0; i < 1000; i++ {
+		// act
+		result := xrand.Email("test.org", "mail.test")
+
+		// assert
+		assertTrue(t, emailReg.MatchString(result))
+		domains[strings.Split(result, "@")[1]]++
+	}
+	assertEqual(t, 2, len(domains))
+}
+
+func TestPhoneNumber(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject = xrand.PhoneNumber
+		tests   = [...]struct {
+			name        string
+			inputFormat string
+			expectedReg *regexp.Regexp
+		}{
+			{
+				name:        "US format",
+				inputFormat: "+1-###-###-####",
+				expectedReg: regexp.MustCompile(`^\+1-\d{3}-\d{3}-\d{4}$`),
+			},
+			{
+				name:        "with parentheses and spaces",
+				inputFormat: "(0###) ### ###",
+				expectedReg: regexp.MustCompile(`^\(0\d{3}\) \d{3} \d{3}$`),
+			},
+			{
+				name:        "only placeholders",
+				inputFormat: "##########",
+				expectedReg: regexp.MustCompile(`^\d{10}$`),
+			},
+			{
+				name:        "multi-byte literals",
+				inputFormat: "☎ ###·###",
+				expectedReg: regexp.MustCompile(`^☎ \d{3}·\d{3}$`),
+			},
+		}
+	)
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			numbers := make(map[string]struct{})
+			for i := 0; i < 200; i++ {
+				// act
+				result := subject(test.inputFormat)
+
+				// assert
+				assertTrue(t, test.expectedReg.MatchString(result))
+				assertEqual(t, len(test.inputFormat), len(result))
+				for j := 0; j < len(result); j++ { // literals are preserved
+					if test.inputFormat[j] != '#' {
+						assertEqual(t, test.inputFormat[j], result[j])
+					}
+				}
+				numbers[result] = struct{}{}
+			}
+			assertTrue(t, len(numbers) > 190)
+		})
+	}
+
+	t.Run("no placeholders", func(t *testing.T) {
+		t.Parallel()
+
+		assertEqual(t, "112", subject("112"))
+		assertEqual(t, "", subject(""))
+	})
+}
+
+func TestTimeZone(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		knownNames = make(map[string]struct{})
+		zones      = make(map[string]struct{})
+	)
+	for _, name := range xrand.TimeZoneNames() {
+		knownNames[name] = struct{}{}
+	}
+
+	for i := 0; i < 200; i++ {
+		// act
+		result := xrand.TimeZone()
+
+		// assert
+		if assertTrue(t, result != nil) {
+			_, known := knownNames[result.String()]
+			assertTrue(t, known)
+			zones[result.String()] = struct{}{}
+		}
+	}
+	assertTrue(t, len(zones) > 1)
+}
+
+func TestTimeZoneNamesAreValid(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range xrand.TimeZoneNames() {
+		_, err := time.LoadLocation(name)
+		assertNil(t, err)
+	}
+}
+
+func TestTimeZoneLoadError(t *testing.T) { // Note: not parallel, as it replaces the global time zone loader.
+	// arrange
+	restore := xrand.SetLoadLocation(func(name string) (*time.Location, error) {
+		if name != "Asia/Tokyo" {
+			return nil, errors.New("unknown time zone " + name)
+		}
+
+		return time.LoadLocation(name)
+	})
+
+	// act
+	result := xrand.TimeZone()
+
+	// assert
+	assertEqual(t, "Asia/Tokyo", result.String()) // the only loadable zone
+	restore()
+
+	// arrange
+	defer xrand.SetLoadLocation(func(name string) (*time.Location, error) {
+		return nil, errors.New("unknown time zone " + name)
+	})()
+
+	// act
+	result = xrand.TimeZone()
+
+	// assert
+	assertEqual(t, time.UTC, result) // fallback
+}
+
+func TestLuhnNumber(t *testing.T) {
+	t.Parallel()
+
+	for _, testData := range [...]int{2, 3, 13, 16, 19} {
+		length := testData // capture range variable
+		t.Run(fmt.Sprintf("len = %d", length), func(t *testing.T) {
+			t.Parallel()
+
+			numbers := make(map[string]struct{})
+			for i := 0; i < 500; i++ {
+				// act
+				result := xrand.LuhnNumber(length)
+
+				// assert
+				assertEqual(t, length, len(result))
+				assertTrue(t, isLuhnValid(result))
+				assertTrue(t, result[0] != '0')
+				numbers[result] = struct{}{}
+			}
+			assertTrue(t, len(numbers) >= 9)
+		})
+	}
+
+	t.Run("known valid numbers are recognized", func(t *testing.T) {
+		t.Parallel()
+
+		assertTrue(t, isLuhnValid("4539578763621486"))
+		assertTrue(t, isLuhnValid("79927398713"))
+		assertTrue(t, !isLuhnValid("79927398710"))
+	})
+
+	t.Run("panics for length < 2", func(t *testing.T) {
+		t.Parallel()
+
+		assertPanics(t, func() { _ = xrand.LuhnNumber(1) })
+		assertPanics(t, func() { _ = xrand.LuhnNumber(0) })
+		assertPanics(t, func() { _ = xrand.LuhnNumber(-1) })
+	})
+}
+
+// isLuhnValid returns whether the numeric string passes the standard Luhn validation.
+func isLuhnValid(number string) bool {
+	sum := 0
+	for i := len(number) - 1; i >= 0; i-- {
+		if number[i] < '0' || number[i] > '9' {
+			return false
+		}
+		digit := int(number[i] - '0')
+		if (len(number)-1-i)%2 == 1 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+
+	return sum%10 == 0
+}
+
+func TestISBN13(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		isbnReg  = regexp.MustCompile(`^97[89][0-9]{10}$`)
+		prefixes = make(map[string]int, 2)
+		isbns    = make(map[string]struct{}, 1000)
+	)
+
+	for i := 0; i < 1000; i++ {
+		// act
+		result := xrand.ISBN13()
+
+		// assert
+		assertEqual(t, 13, len(result))
+		assertTrue(t, isbnReg.MatchString(result))
+		assertTrue(t, isISBN13Valid(result))
+		prefixes[result[:3]]++
+		isbns[result] = struct{}{}
+	}
+	assertEqual(t, 2, len(prefixes))
+	assertTrue(t, len(isbns) > 990)
+
+	t.Run("known valid ISBNs are recognized", func(t *testing.T) {
+		t.Parallel()
+
+		assertTrue(t, isISBN13Valid("9780306406157"))
+		assertTrue(t, isISBN13Valid("9791090636071"))
+		assertTrue(t, !isISBN13Valid("9780306406158"))
+	})
+}
+
+// isISBN13Valid returns whether the numeric string passes the ISBN-13 check digit validation.
+func isISBN13Valid(isbn string) bool {
+	sum := 0
+	for i := 0; i < len(isbn); i++ {
+		if isbn[i] < '0' || isbn[i] > '9' {
+			return false
+		}
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += weight * int(isbn[i]-'0')
+	}
+
+	return len(isbn) == 13 && sum%10 == 0
+}
+
+// semVerReg matches a semantic version, with an optional pre-release, as suggested at
+// https://semver.org/#is-there-a-suggested-regular-expression-regex-to-check-a-semver-string,
+// without the build metadata part.
+var semVerReg = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?$`)
+
+func TestSemVer(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	versions := make(map[string]struct{})
+
+	for i := 0; i < 1000; i++ {
+		// act
+		result := xrand.SemVer()
+
+		// assert
+		assertTrue(t, semVerReg.MatchString(result))
+		assertTrue(t, !strings.Contains(result, "-"))
+		versions[result] = struct{}{}
+	}
+	assertTrue(t, len(versions) > 500)
+}
+
+func TestSemVerPreRelease(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		preReleaseReg = regexp.MustCompile(`^\d+\.\d+\.\d+-(alpha|beta|rc)\.[1-9]$`)
+		labels        = make(map[string]struct{})
+	)
+
+	for i := 0; i < 1000; i++ {
+		// act
+		result := xrand.SemVerPreRelease()
+
+		// assert
+		assertTrue(t, semVerReg.MatchString(result))
+		assertTrue(t, preReleaseReg.MatchString(result))
+		labels[preReleaseReg.FindStringSubmatch(result)[1]] = struct{}{}
+	}
+	assertEqual(t, 3, len(labels))
+}
+
+func TestHTTPMethod(t *testing.T) {
+	t.Parallel()
+
+	t.Run("distribution matches weights", testHTTPMethodDistribution)
+	t.Run("default distribution favors GET", testHTTPMethodDefault)
+	t.Run("panics for invalid input", testHTTPMethodPanics)
+}
+
+func testHTTPMethodDistribution(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 50000
+	var (
+		weights = map[string]float64{"GET": 5, "POST": 3, "DELETE": 2, "TRACE": 0}
+		methods = []string{"GET", "POST", "DELETE", "TRACE"}
+		counts  = make(map[string]int, len(weights))
+	)
+
+	for i := 0; i < iterations; i++ {
+		// act
+		result := xrand.HTTPMethod(weights)
+
+		// assert
+		counts[result]++
+	}
+	assertEqual(t, len(methods)-1, len(counts)) // only the weighted methods
+	assertWeightedDistribution(t, methods, []float64{5, 3, 2, 0}, counts, iterations)
+}
+
+func testHTTPMethodDefault(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 50000
+	var (
+		methods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+		counts  = make(map[string]int, len(methods))
+	)
+
+	for i := 0; i < iterations; i++ {
+		// act
+		result := xrand.HTTPMethod(nil)
+
+		// assert
+		counts[result]++
+	}
+	assertEqual(t, len(methods), len(counts))
+	assertWeightedDistribution(t, methods, []float64{60, 20, 8, 5, 5, 1, 1}, counts, iterations)
+	for _, method := range methods[1:] {
+		assertTrue(t, counts["GET"] > counts[method])
+	}
+}
+
+func testHTTPMethodPanics(t *testing.T) {
+	t.Parallel()
+
+	// act & assert
+	assertPanics(t, func() { _ = xrand.HTTPMethod(map[string]float64{"GET": 1, "FETCH": 1}) })
+	assertPanics(t, func() { _ = xrand.HTTPMethod(map[string]float64{"get": 1}) })
+	assertPanics(t, func() { _ = xrand.HTTPMethod(map[string]float64{"GET": -1}) })
+	assertPanics(t, func() { _ = xrand.HTTPMethod(map[string]float64{"GET": 0, "POST": 0}) })
+}
+
+func ExampleLatLng() {
+	// generate a random coordinate on the globe.
+	lat, lng := xrand.LatLng()
+	fmt.Printf("%.6f,%.6f\n", lat, lng)
+}
+
+func ExampleLatLngInBox() {
+	// generate a random coordinate within Romania's bounding box.
+	lat, lng := xrand.LatLngInBox(43.6, 20.2, 48.3, 29.7)
+	fmt.Printf("%.6f,%.6f\n", lat, lng)
+}
+
+func ExampleEmail() {
+	// generate a random email for a test user.
+	email := xrand.Email("test.org")
+	fmt.Println(email)
+}
+
+func ExamplePhoneNumber() {
+	// generate a random US phone number for an anonymized user.
+	phone := xrand.PhoneNumber("+1-###-###-####")
+	fmt.Println(phone)
+}
+
+func ExampleTimeZone() {
+	// generate a random local time for a test user.
+	loc := xrand.TimeZone()
+	fmt.Println(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC).In(loc))
+}
+
+func ExampleLuhnNumber() {
+	// generate a synthetic 16 digits card number for a payment test.
+	cardNumber := xrand.LuhnNumber(16)
+	fmt.Println(cardNumber)
+}
+
+func ExampleISBN13() {
+	// generate a book fixture.
+	fmt.Println(xrand.ISBN13()) // like 9781234567897
+}
+
+func ExampleSemVer() {
+	// generate a random version for a test package.
+	version := xrand.SemVer()
+	fmt.Println("v" + version)
+}
+
+func ExampleSemVerPreRelease() {
+	// generate a random pre-release version, like "1.4.2-beta.3".
+	version := xrand.SemVerPreRelease()
+	fmt.Println("v" + version)
+}
+
+func ExampleHTTPMethod() {
+	// a read-heavy load test.
+	method := xrand.HTTPMethod(map[string]float64{"GET": 90, "POST": 10})
+	fmt.Println(method) // like GET
+}
@@ -0,0 +1,96 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand
+
+import "math"
+
+const (
+	// maxFloat64Decimals is the max no. of decimals [Float64Round] supports.
+	maxFloat64Decimals = 15
+	// maxFloat64RoundUnits is the (exclusive) max magnitude of the bounds, in units of 10^-decimals,
+	// [Float64Round] supports, so that every no. of units is exactly representable as a float64,
+	// and dividing it by the scale is the only rounding made.
+	maxFloat64RoundUnits = 1 << 53
+)
+
+// Float64Round generates a random float64 in range [min,max), rounded to given no. of decimals,
+// useful for generating data like currency amounts (decimals = 2).
+// All values with at most decimals decimal places from [min,max) have the same probability.
+// The returned float64 is the closest representation of such a value, formatting it
+// (with the shortest representation, like strconv.FormatFloat(f, 'f', -1, 64))
+// never displays more than decimals decimal places.
+// decimals is clamped to [0, 15].
+// It panics if no such value exists in [min,max), or if |min| or |max| multiplied by 10^decimals
+// is not below 2^53 (about 9e15), like for min = 0, max = 10 and decimals = 15;
+// fewer decimals should be requested for such magnitudes.
+func Float64Round(min, max float64, decimals int) float64 {
+	if decimals < 0 {
+		decimals = 0
+	} else if decimals > maxFloat64Decimals {
+		decimals = maxFloat64Decimals
+	}
+
+	// work with integer no. of units of 10^-decimals, avoiding float representation surprises.
+	scale := math.Pow10(decimals)
+	// Note: negated conditions also catch NaN.
+	if !(math.Abs(min)*scale < maxFloat64RoundUnits) || !(math.Abs(max)*scale < maxFloat64RoundUnits) {
+		panic("invalid argument to Float64Round")
+	}
+	var (
+		lo = ceilUnits(min, scale)
+		hi = ceilUnits(max, scale) // exclusive
+	)
+	if hi <= lo {
+		panic("invalid argument to Float64Round")
+	}
+
+	return float64(lo+globalRand.Int63n(hi-lo)) / scale
+}
+
+// ceilUnits returns the smallest integer k, for which k/scale >= f.
+// |f|*scale is expected to be below maxFloat64RoundUnits.
+func ceilUnits(f, scale float64) int64 {
+	k := int64(math.Round(f * scale))
+	for float64(k)/scale < f {
+		k++
+	}
+	for float64(k-1)/scale >= f {
+		k--
+	}
+
+	return k
+}
+
+// FloatMatrix generates a rows x cols matrix of random float64s, uniformly distributed in range [min,max),
+// useful for ML / test fixtures.
+// Elements are allocated at once, each row being a slice with its capacity capped to cols,
+// so that appending to a row never overwrites the next one.
+// It panics if rows < 0, cols < 0, min >= max or a bound is infinite.
+func FloatMatrix(rows, cols int, min, max float64) [][]float64 {
+	// Note: negated condition also catches NaN.
+	if rows < 0 || cols < 0 || !(min < max) || math.IsInf(min, -1) || math.IsInf(max, 1) {
+		panic("invalid argument to FloatMatrix")
+	}
+
+	var (
+		matrix   = make([][]float64, rows)
+		elements = make([]float64, rows*cols)
+	)
+	for i := range elements {
+		// Note: not min + u*(max-min), as max-min can overflow to +Inf for extreme bounds.
+		u := Float64()
+		element := min*(1-u) + max*u
+		if element >= max { // float rounding errors.
+			element = math.Nextafter(max, min)
+		}
+		elements[i] = element
+	}
+	for i := range matrix {
+		matrix[i] = elements[i*cols : (i+1)*cols : (i+1)*cols]
+	}
+
+	return matrix
+}
@@ -0,0 +1,197 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand_test
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/actforgood/xrand"
+)
+
+func TestFloat64Round(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject = xrand.Float64Round
+		tests   = [...]struct {
+			name             string
+			inputMin         float64
+			inputMax         float64
+			inputDecimals    int
+			expectedDecimals int
+		}{
+			{name: "currency", inputMin: 0.01, inputMax: 1000, inputDecimals: 2, expectedDecimals: 2},
+			{name: "tricky bounds", inputMin: 1.1, inputMax: 1.15, inputDecimals: 2, expectedDecimals: 2},
+			{name: "negative range", inputMin: -5.5, inputMax: -0.25, inputDecimals: 3, expectedDecimals: 3},
+			{name: "range crossing zero", inputMin: -1, inputMax: 1, inputDecimals: 1, expectedDecimals: 1},
+			{name: "integers", inputMin: 0.5, inputMax: 10.5, inputDecimals: 0, expectedDecimals: 0},
+			{name: "negative decimals", inputMin: 0, inputMax: 100, inputDecimals: -2, expectedDecimals: 0},
+			{name: "many decimals", inputMin: 0, inputMax: 1, inputDecimals: 6, expectedDecimals: 6},
+			{name: "too many decimals", inputMin: 0, inputMax: 1, inputDecimals: 20, expectedDecimals: 15},
+			{name: "large bounds, many decimals", inputMin: 0, inputMax: 9, inputDecimals: 15, expectedDecimals: 15},
+			{name: "large bounds crossing zero", inputMin: -9, inputMax: 9, inputDecimals: 15, expectedDecimals: 15},
+		}
+	)
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			for i := 0; i < 1000; i++ {
+				// act
+				result := subject(test.inputMin, test.inputMax, test.inputDecimals)
+
+				// assert
+				assertTrue(t, result >= test.inputMin)
+				assertTrue(t, result < test.inputMax)
+				assertTrue(t, countDecimals(result) <= test.expectedDecimals)
+			}
+		})
+	}
+
+	t.Run("all values are reachable", func(t *testing.T) {
+		// arrange
+		seen := make(map[float64]struct{}, 5)
+
+		// act
+		for i := 0; i < 1000; i++ {
+			seen[subject(1.1, 1.15, 2)] = struct{}{}
+		}
+
+		// assert
+		assertEqual(t, 5, len(seen)) // 1.1, 1.11, 1.12, 1.13, 1.14
+	})
+
+	t.Run("panics if no value exists in range", func(t *testing.T) {
+		// act & assert
+		assertPanics(t, func() {
+			_ = subject(0.11, 0.12, 1)
+		})
+	})
+
+	t.Run("panics if bounds in units overflow", func(t *testing.T) {
+		// act & assert
+		assertPanics(t, func() { _ = subject(0, 10, 15) })
+		assertPanics(t, func() { _ = subject(0, 9000, 15) })
+		assertPanics(t, func() { _ = subject(-9000, 9000, 15) })
+		assertPanics(t, func() { _ = subject(0, 10000, 15) })
+		assertPanics(t, func() { _ = subject(0, 1e12, 8) })
+		assertPanics(t, func() { _ = subject(-1e5, 0, 15) })
+		assertPanics(t, func() { _ = subject(0, 1e17, 2) })
+		assertPanics(t, func() { _ = subject(math.Inf(-1), 0, 2) })
+		assertPanics(t, func() { _ = subject(0, math.NaN(), 2) })
+	})
+}
+
+func TestFloatMatrix(t *testing.T) {
+	t.Parallel()
+
+	t.Run("dimensions and range", testFloatMatrixDimensions)
+	t.Run("rows do not share memory", testFloatMatrixRowsIndependence)
+	t.Run("panics for invalid arguments", testFloatMatrixPanics)
+}
+
+func testFloatMatrixDimensions(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	tests := [...]struct {
+		rows int
+		cols int
+		min  float64
+		max  float64
+	}{
+		{rows: 3, cols: 4, min: 0, max: 1},
+		{rows: 1, cols: 100, min: -5, max: 5},
+		{rows: 50, cols: 1, min: 1e6, max: 1e6 + 0.001},
+		{rows: 0, cols: 10, min: 0, max: 1},
+		{rows: 10, cols: 0, min: 0, max: 1},
+		{rows: 10, cols: 10, min: -math.MaxFloat64, max: math.MaxFloat64},
+		{rows: 10, cols: 10, min: -math.MaxFloat64, max: -math.MaxFloat64 / 2},
+	}
+
+	for _, test := range tests {
+		// act
+		result := xrand.FloatMatrix(test.rows, test.cols, test.min, test.max)
+
+		// assert
+		assertEqual(t, test.rows, len(result))
+		distinct := make(map[float64]struct{})
+		for _, row := range result {
+			assertEqual(t, test.cols, len(row))
+			for _, element := range row {
+				assertTrue(t, element >= test.min)
+				assertTrue(t, element < test.max)
+				distinct[element] = struct{}{}
+			}
+		}
+		if test.rows*test.cols > 1 {
+			assertTrue(t, len(distinct) > 1)
+		}
+	}
+}
+
+func testFloatMatrixRowsIndependence(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject   = xrand.FloatMatrix(3, 2, 0, 1)
+		secondRow = append([]float64(nil), subject[1]...)
+	)
+
+	// act
+	subject[0][1] = 10
+	subject[0] = append(subject[0], 20, 30)
+
+	// assert
+	assertEqual(t, 4, len(subject[0]))
+	assertEqual(t, 10.0, subject[0][1])
+	for j := range secondRow {
+		assertEqual(t, secondRow[j], subject[1][j])
+	}
+}
+
+func testFloatMatrixPanics(t *testing.T) {
+	t.Parallel()
+
+	// act & assert
+	assertPanics(t, func() { _ = xrand.FloatMatrix(-1, 2, 0, 1) })
+	assertPanics(t, func() { _ = xrand.FloatMatrix(2, -1, 0, 1) })
+	assertPanics(t, func() { _ = xrand.FloatMatrix(2, 2, 1, 1) })
+	assertPanics(t, func() { _ = xrand.FloatMatrix(2, 2, 1, 0) })
+	assertPanics(t, func() { _ = xrand.FloatMatrix(2, 2, math.Inf(-1), 0) })
+	assertPanics(t, func() { _ = xrand.FloatMatrix(2, 2, 0, math.Inf(1)) })
+	assertPanics(t, func() { _ = xrand.FloatMatrix(2, 2, 0, math.NaN()) })
+}
+
+// countDecimals returns the no. of decimal places of f's shortest representation.
+func countDecimals(f float64) int {
+	str := strconv.FormatFloat(f, 'f', -1, 64)
+	if idx := strings.IndexByte(str, '.'); idx >= 0 {
+		return len(str) - idx - 1
+	}
+
+	return 0
+}
+
+func ExampleFloat64Round() {
+	// generate a random price in [1.00, 100.00).
+	price := xrand.Float64Round(1, 100, 2)
+	fmt.Printf("$%.2f\n", price)
+}
+
+func ExampleFloatMatrix() {
+	// generate initial weights for a 3x4 layer, in [-0.5, 0.5).
+	weights := xrand.FloatMatrix(3, 4, -0.5, 0.5)
+	for _, row := range weights {
+		fmt.Printf("%.3f\n", row)
+	}
+}
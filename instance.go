@@ -0,0 +1,98 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand
+
+import (
+	"hash/fnv"
+	mRand "math/rand"
+)
+
+// Rand is a generator of random values, which, unlike package level functions
+// that use a securely seeded global source, is explicitly seeded.
+// Rands created with the same seed produce the same sequence of values,
+// which makes them useful for reproducible results, like in tests.
+// A Rand is not safe for concurrent use by multiple goroutines.
+type Rand struct {
+	src *pcgSource
+	r   *mRand.Rand
+}
+
+// New returns a new Rand seeded with given seed.
+func New(seed int64) *Rand {
+	src := newPCGSource(seed)
+
+	return &Rand{src: src, r: mRand.New(src)}
+}
+
+// Int63 generates a random non-negative int64.
+func (r *Rand) Int63() int64 {
+	return r.r.Int63()
+}
+
+// Intn generates a random integer in range [0,n).
+// It panics if n <= 0.
+func (r *Rand) Intn(n int) int {
+	return r.r.Intn(n)
+}
+
+// IntnBetween generates a random integer in range [min,max).
+// It panics if max <= min.
+func (r *Rand) IntnBetween(min, max int) int {
+	return r.r.Intn(max-min) + min
+}
+
+// Float64 generates a random float64 in range [0.0, 1.0).
+func (r *Rand) Float64() float64 {
+	return r.r.Float64()
+}
+
+// Peek returns the next value [Rand.Int63] will return, without consuming it.
+// It is useful for debugging, as two consecutive calls return the same value.
+func (r *Rand) Peek() int64 {
+	snapshot := *r.src
+
+	return snapshot.Int63()
+}
+
+// MarshalBinary returns the generator's state, implementing [encoding.BinaryMarshaler].
+// Together with [Rand.UnmarshalBinary], it allows capturing the state and later restoring it,
+// to replay the same sequence of values.
+func (r *Rand) MarshalBinary() ([]byte, error) {
+	return r.src.MarshalBinary()
+}
+
+// UnmarshalBinary restores the generator's state, previously obtained with [Rand.MarshalBinary],
+// implementing [encoding.BinaryUnmarshaler].
+// It returns [ErrInvalidState] if data is not a valid state.
+func (r *Rand) UnmarshalBinary(data []byte) error {
+	return r.src.UnmarshalBinary(data)
+}
+
+// PickWith returns a random element from items, using r as the random generator.
+// It panics if items is empty.
+func PickWith[T any](r *Rand, items []T) T {
+	return items[r.r.Intn(len(items))]
+}
+
+// ShuffleWith shuffles items in place, using r as the random generator.
+func ShuffleWith[T any](r *Rand, items []T) {
+	r.r.Shuffle(len(items), func(i, j int) {
+		items[i], items[j] = items[j], items[i]
+	})
+}
+
+// PermForKey returns a pseudo-random permutation of [0,n), deterministically derived from key:
+// the same key always produces the same permutation, while different keys produce different ones.
+// It is useful for a consistent, but pseudo-random ordering per tenant / user, for example.
+// The seed of a local generator is derived from key's FNV-1a hash, thus the result is
+// not suitable for cryptographic purposes, as it is predictable from the key.
+// It panics if n < 0.
+func PermForKey(key string, n int) []int {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(key)) // Note: never returns an error.
+
+	return New(int64(hash.Sum64())).r.Perm(n)
+}
@@ -0,0 +1,348 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand_test
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/actforgood/xrand"
+)
+
+func TestRand(t *testing.T) {
+	t.Parallel()
+
+	t.Run("equally seeded Rands produce the same values", testRandSameSeed)
+	t.Run("differently seeded Rands diverge", testRandDifferentSeed)
+	t.Run("values are in range", testRandInRange)
+}
+
+func testRandSameSeed(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject1 = xrand.New(1234)
+		subject2 = xrand.New(1234)
+	)
+
+	// act
+	values1 := randValues(subject1)
+	values2 := randValues(subject2)
+
+	// assert
+	assertTrue(t, reflect.DeepEqual(values1, values2))
+}
+
+func testRandDifferentSeed(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject1 = xrand.New(1234)
+		subject2 = xrand.New(4321)
+	)
+
+	// act
+	values1 := randValues(subject1)
+	values2 := randValues(subject2)
+
+	// assert
+	assertTrue(t, !reflect.DeepEqual(values1.picks, values2.picks))
+	assertTrue(t, !reflect.DeepEqual(values1.shuffled, values2.shuffled))
+	assertTrue(t, values1.int63 != values2.int63)
+}
+
+func testRandInRange(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	subject := xrand.New(1234)
+
+	for i := 0; i < 1000; i++ {
+		// act
+		n := subject.Intn(10)
+		between := subject.IntnBetween(5, 10)
+		f := subject.Float64()
+		int63 := subject.Int63()
+
+		// assert
+		assertTrue(t, n >= 0 && n < 10)
+		assertTrue(t, between >= 5 && between < 10)
+		assertTrue(t, f >= 0.0 && f < 1.0)
+		assertTrue(t, int63 >= 0)
+	}
+}
+
+func TestRandPeek(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	subject := xrand.New(1234)
+
+	for i := 0; i < 100; i++ {
+		// act
+		peek1 := subject.Peek()
+		peek2 := subject.Peek()
+		drawn := subject.Int63()
+
+		// assert
+		assertEqual(t, peek1, peek2)
+		assertEqual(t, peek1, drawn)
+		_ = subject.Intn(100) // other draws do not interfere.
+	}
+}
+
+func TestRandMarshalling(t *testing.T) {
+	t.Parallel()
+
+	t.Run("restored state replays the same sequence", testRandMarshallingReplay)
+	t.Run("state can be restored into another Rand", testRandMarshallingAnotherRand)
+	t.Run("error for invalid state", testRandMarshallingInvalidState)
+}
+
+func testRandMarshallingReplay(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	subject := xrand.New(1234)
+	_ = randValues(subject) // advance mid-stream
+
+	// act
+	state, err := subject.MarshalBinary()
+	assertNil(t, err)
+	values1 := randValues(subject)
+	err = subject.UnmarshalBinary(state)
+	assertNil(t, err)
+	values2 := randValues(subject)
+
+	// assert
+	assertTrue(t, reflect.DeepEqual(values1, values2))
+}
+
+func testRandMarshallingAnotherRand(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject1 = xrand.New(1234)
+		subject2 = xrand.New(4321)
+	)
+	_ = subject1.Int63()
+
+	// act
+	state, err := subject1.MarshalBinary()
+	assertNil(t, err)
+	err = subject2.UnmarshalBinary(state)
+	assertNil(t, err)
+
+	// assert
+	assertTrue(t, reflect.DeepEqual(randValues(subject1), randValues(subject2)))
+}
+
+func testRandMarshallingInvalidState(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject  = xrand.New(1234)
+		expected = subject.Peek()
+		states   = [...][]byte{
+			nil,
+			[]byte("pcg:"),
+			[]byte("abc:0123456789abcdef"),
+			[]byte("pcg:0123456789abcdef0"),
+		}
+	)
+
+	for _, state := range states {
+		// act
+		err := subject.UnmarshalBinary(state)
+
+		// assert
+		assertTrue(t, errors.Is(err, xrand.ErrInvalidState))
+		assertEqual(t, expected, subject.Peek()) // state is left unchanged
+	}
+}
+
+func TestRandIsUniform(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 50000
+	var (
+		subject = xrand.New(5678)
+		counts  = make([]int, 10)
+	)
+
+	// act
+	for i := 0; i < iterations; i++ {
+		counts[subject.Intn(len(counts))]++
+	}
+
+	// assert
+	assertUniform(t, counts, iterations, 0.05)
+}
+
+func TestShuffleWith(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject = xrand.ShuffleWith[int]
+		items   = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	)
+
+	// act
+	subject(xrand.New(1), items)
+
+	// assert
+	sorted := append([]int(nil), items...)
+	sort.Ints(sorted)
+	assertTrue(t, reflect.DeepEqual([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, sorted))
+}
+
+func TestPermForKey(t *testing.T) {
+	t.Parallel()
+
+	t.Run("same key, same permutation", testPermForKeySameKey)
+	t.Run("different keys, different permutations", testPermForKeyDifferentKeys)
+	t.Run("stable across releases", testPermForKeyStable)
+	t.Run("panics for negative n", testPermForKeyPanics)
+}
+
+func testPermForKeySameKey(t *testing.T) {
+	t.Parallel()
+
+	for _, n := range [...]int{0, 1, 2, 10, 100} {
+		// act
+		result1 := xrand.PermForKey("tenant-1", n)
+		result2 := xrand.PermForKey("tenant-1", n)
+
+		// assert
+		assertEqual(t, n, len(result1))
+		assertTrue(t, reflect.DeepEqual(result1, result2))
+		sorted := append([]int(nil), result1...)
+		sort.Ints(sorted)
+		for i, v := range sorted { // is a valid permutation
+			assertEqual(t, i, v)
+		}
+	}
+}
+
+func testPermForKeyDifferentKeys(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	perms := make(map[string]struct{})
+
+	for i := 0; i < 100; i++ {
+		// act
+		result := xrand.PermForKey(fmt.Sprintf("tenant-%d", i), 16)
+
+		// assert
+		perms[fmt.Sprint(result)] = struct{}{}
+	}
+	assertTrue(t, len(perms) > 95)
+}
+
+func testPermForKeyStable(t *testing.T) {
+	t.Parallel()
+
+	// act
+	result1 := xrand.PermForKey("tenant-42", 10)
+	result2 := xrand.PermForKey("", 5)
+
+	// assert
+	assertTrue(t, reflect.DeepEqual([]int{1, 2, 5, 3, 8, 7, 0, 6, 4, 9}, result1))
+	assertTrue(t, reflect.DeepEqual([]int{3, 2, 1, 4, 0}, result2))
+}
+
+func testPermForKeyPanics(t *testing.T) {
+	t.Parallel()
+
+	assertPanics(t, func() { _ = xrand.PermForKey("tenant-1", -1) })
+}
+
+// randGenerated holds values generated by a Rand.
+type randGenerated struct {
+	int63    int64
+	float    float64
+	picks    []string
+	shuffled []int
+}
+
+// randValues generates some values with given Rand.
+func randValues(r *xrand.Rand) randGenerated {
+	var (
+		items    = []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+		shuffled = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+		values   = randGenerated{
+			int63: r.Int63(),
+			float: r.Float64(),
+			picks: make([]string, 20),
+		}
+	)
+	for i := range values.picks {
+		values.picks[i] = xrand.PickWith(r, items)
+	}
+	xrand.ShuffleWith(r, shuffled)
+	values.shuffled = shuffled
+
+	return values
+}
+
+func ExampleRand_Peek() {
+	// preview the next random value, without consuming it.
+	r := xrand.New(2024)
+	next := r.Peek()
+	fmt.Println(next == r.Int63())
+	// Output: true
+}
+
+func ExampleRand_MarshalBinary() {
+	r := xrand.New(2024)
+
+	// capture the state.
+	state, _ := r.MarshalBinary()
+	first := r.Intn(1000)
+
+	// restore the state, and replay.
+	_ = r.UnmarshalBinary(state)
+	fmt.Println(first == r.Intn(1000))
+	// Output: true
+}
+
+func ExampleNew() {
+	// reproducible random values, by using a fixed seed.
+	r := xrand.New(2024)
+	items := []string{"a", "b", "c", "d"}
+	xrand.ShuffleWith(r, items)
+	fmt.Println(r.Intn(100), xrand.PickWith(r, items), items)
+}
+
+func ExamplePermForKey() {
+	// order the same servers differently per tenant, but always the same for a given tenant.
+	servers := []string{"server1", "server2", "server3", "server4"}
+	for _, idx := range xrand.PermForKey("tenant-42", len(servers)) {
+		fmt.Println(servers[idx])
+	}
+
+	// Output:
+	// server2
+	// server3
+	// server1
+	// server4
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*xrand.Rand)(nil)
+	_ encoding.BinaryUnmarshaler = (*xrand.Rand)(nil)
+)
@@ -0,0 +1,201 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand
+
+import (
+	"math"
+	"time"
+)
+
+// jitterFactor returns the jitter factor to apply, from the optional max factor.
+// If max factor is not provided or is <= 0.0, [defaultJitterFactor] is returned.
+func jitterFactor(maxFactor []float64) float64 {
+	if len(maxFactor) > 0 && maxFactor[0] > 0.0 {
+		return maxFactor[0]
+	}
+
+	return defaultJitterFactor
+}
+
+// JitterInt returns n altered with a random factor, rounded to the nearest integer.
+// It is the integer counterpart of [Jitter], useful for quantities like batch sizes, retry counts.
+// The result is guaranteed to be >= 1 (for n < 1, 1 is returned).
+// If maxFactor is <= 0.0, a suggested default value will be chosen.
+func JitterInt(n int, maxFactor ...float64) int {
+	if n < 1 {
+		return 1
+	}
+
+	factor := jitterFactor(maxFactor)
+	newN := 0
+	for newN < 1 {
+		randRange := 2*jitterFloat64() - 1 // [-1.0, 1.0)
+		newN = int(math.Round(float64(n) + randRange*factor*float64(n)))
+	}
+
+	return newN
+}
+
+// PickDuration returns a random duration picked from options, altered with [Jitter].
+// It is useful to choose a TTL from a quantized policy, avoiding cache stampedes.
+// If maxFactor is <= 0.0, a suggested default value will be chosen.
+// It panics if options is empty.
+func PickDuration(options []time.Duration, maxFactor ...float64) time.Duration {
+	return Jitter(options[globalRand.Intn(len(options))], maxFactor...)
+}
+
+// JitterRandomFactor returns a time.Duration altered with a random factor,
+// whose magnitude is itself random, picked uniformly from [minFactor, maxFactor).
+// This produces more diverse spreads across a fleet than a fixed max factor.
+// A negative minFactor is treated as 0.0. If maxFactor <= minFactor,
+// minFactor is used as the fixed max factor.
+func JitterRandomFactor(duration time.Duration, minFactor, maxFactor float64) time.Duration {
+	if minFactor < 0.0 {
+		minFactor = 0.0
+	}
+	factor := minFactor
+	if maxFactor > minFactor {
+		factor += jitterFloat64() * (maxFactor - minFactor)
+	}
+
+	return jitterDuration(duration, factor)
+}
+
+// JitterAll returns a new slice with each of the durations independently altered with [Jitter].
+// Input durations are not modified.
+// If maxFactor is <= 0.0, a suggested default value will be chosen.
+func JitterAll(durations []time.Duration, maxFactor ...float64) []time.Duration {
+	var (
+		factor   = jitterFactor(maxFactor)
+		jittered = make([]time.Duration, len(durations))
+	)
+	for i, duration := range durations {
+		jittered[i] = jitterDuration(duration, factor)
+	}
+
+	return jittered
+}
+
+// JitterPercent returns a time.Duration altered with a random factor, expressed as a percent
+// on a 0-100 scale, like config files often do ("±20%"): JitterPercent(d, 20) is equivalent to Jitter(d, 0.2).
+// If percent is <= 0.0, a suggested default value will be chosen.
+func JitterPercent(duration time.Duration, percent float64) time.Duration {
+	return Jitter(duration, percent/100)
+}
+
+// JitterMin returns a time.Duration altered with a random factor, like [Jitter], but guaranteed
+// to be at least minDuration, instead of just positive.
+// This matters when jittering small durations, where symmetric jitter can nearly zero them out.
+// The result is uniformly distributed over the part of the jitter band that is >= minDuration.
+// If the whole jitter band is below minDuration, minDuration is returned.
+// If minDuration is <= 0, it behaves like [Jitter].
+// If maxFactor is <= 0.0, a suggested default value will be chosen.
+func JitterMin(duration, minDuration time.Duration, maxFactor ...float64) time.Duration {
+	if minDuration <= 0 {
+		return Jitter(duration, maxFactor...)
+	}
+
+	var (
+		factor = jitterFactor(maxFactor)
+		upper  = float64(duration) * (1 + factor)
+		lower  = math.Max(float64(duration)*(1-factor), float64(minDuration))
+	)
+	if upper <= lower {
+		return minDuration
+	}
+
+	return time.Duration(lower + jitterFloat64()*(upper-lower))
+}
+
+// JitterSigned returns a time.Duration altered with a random factor, in range
+// [duration - maxFactor*|duration|, duration + maxFactor*|duration|].
+// Unlike [Jitter], duration can be negative (like a clock-skew correction offset), and
+// the result is not forced to be positive: the jitter band is symmetric around duration,
+// so the result can be zero or, for a maxFactor >= 1.0, even cross sign.
+// If maxFactor is <= 0.0, a suggested default value will be chosen.
+func JitterSigned(duration time.Duration, maxFactor ...float64) time.Duration {
+	randRange := 2*jitterFloat64() - 1 // [-1.0, 1.0)
+
+	return duration + time.Duration(randRange*jitterFactor(maxFactor)*float64(duration))
+}
+
+const (
+	// loadAwareJitterFactorStep is the increase of [LoadAwareJitter]'s factor each time concurrency doubles.
+	loadAwareJitterFactorStep = 0.1
+	// maxLoadAwareJitterFactor is [LoadAwareJitter]'s max factor, lower than 1.0 to keep results positive.
+	maxLoadAwareJitterFactor = 0.9
+)
+
+// LoadAwareJitter returns base altered with a random factor, like [Jitter], but widening the jitter band
+// as the no. of concurrent clients grows, to spread retries more aggressively under higher contention.
+// The factor is 0.2 for a concurrency <= 1, increasing by 0.1 each time concurrency doubles
+// (0.2 + 0.1*log2(concurrency)), capped at 0.9 (reached for a concurrency of 128).
+// As the factor is always < 1.0, the result is always positive, in range [base*(1-factor), base*(1+factor)).
+// It panics if base <= 0.
+func LoadAwareJitter(base time.Duration, concurrency int) time.Duration {
+	if base <= 0 {
+		panic("invalid argument to LoadAwareJitter")
+	}
+
+	factor := defaultJitterFactor
+	if concurrency > 1 {
+		factor = math.Min(
+			defaultJitterFactor+loadAwareJitterFactorStep*math.Log2(float64(concurrency)),
+			maxLoadAwareJitterFactor,
+		)
+	}
+
+	return jitterDuration(base, factor)
+}
+
+// JitterDeadline returns an absolute deadline, the current time plus base altered with [Jitter],
+// convenient for schedulers and context.WithDeadline.
+// If maxFactor is <= 0.0, a suggested default value will be chosen.
+func JitterDeadline(base time.Duration, maxFactor ...float64) time.Time {
+	return now().Add(Jitter(base, maxFactor...))
+}
+
+// JitterSeconds returns seconds altered with a random factor, like [Jitter], for callers expressing
+// durations as float seconds, avoiding round-trips through time.Duration.
+// The result is guaranteed to be positive.
+// If maxFactor is <= 0.0, a suggested default value will be chosen.
+// It panics if seconds <= 0 (or NaN / infinite).
+func JitterSeconds(seconds float64, maxFactor ...float64) float64 {
+	if !(seconds > 0) || math.IsInf(seconds, 1) { // Note: negated condition also catches NaN.
+		panic("invalid argument to JitterSeconds")
+	}
+
+	factor := jitterFactor(maxFactor)
+	newSeconds := 0.0
+	for newSeconds <= 0 {
+		randRange := 2*jitterFloat64() - 1 // [-1.0, 1.0)
+		newSeconds = seconds + randRange*factor*seconds
+	}
+
+	return newSeconds
+}
+
+// oneDay is the duration of a day, as considered by [JitterTimeOfDay].
+const oneDay = 24 * time.Hour
+
+// JitterTimeOfDay returns base, an offset into the day (like 3*time.Hour for 03:00), altered with
+// a uniformly random offset in [-spread, spread), wrapped to stay within the day, [0, 24h).
+// It is useful to spread fixed daily schedules (cron-like jobs) across a fleet:
+// a base of 23:50 with a spread of 30m gives a time between 23:20 and 00:20 (the next day's 00:20 being
+// returned as 20m).
+// A spread <= 0 means no jitter, while a spread above 12h is treated as 12h, the whole day being covered.
+// Base is wrapped to the day, too.
+func JitterTimeOfDay(base, spread time.Duration) time.Duration {
+	if spread > oneDay/2 {
+		spread = oneDay / 2
+	}
+	offset := base % oneDay
+	if spread > 0 {
+		offset += time.Duration((2*jitterFloat64() - 1) * float64(spread)) // [-spread, spread)
+	}
+
+	return ((offset % oneDay) + oneDay) % oneDay // Note: % keeps the sign of the dividend.
+}
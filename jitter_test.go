@@ -0,0 +1,1003 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/actforgood/xrand"
+)
+
+func TestJitterInt(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject = xrand.JitterInt
+		tests   = [...]struct {
+			name           string
+			inputN         int
+			inputMaxFactor []float64
+			expectedMin    int
+			expectedMax    int
+		}{
+			{
+				name:        "10, default factor",
+				inputN:      10,
+				expectedMin: 8, // jitter = [-2, 2]
+				expectedMax: 12,
+			},
+			{
+				name:        "1000, default factor",
+				inputN:      1000,
+				expectedMin: 800, // jitter = [-200, 200]
+				expectedMax: 1200,
+			},
+			{
+				name:           "100, factor 0.5",
+				inputN:         100,
+				inputMaxFactor: []float64{0.5},
+				expectedMin:    50, // jitter = [-50, 50]
+				expectedMax:    150,
+			},
+			{
+				name:           "37, factor 0.1",
+				inputN:         37,
+				inputMaxFactor: []float64{0.1},
+				expectedMin:    33, // jitter = [-3.7, 3.7]
+				expectedMax:    41,
+			},
+			{
+				name:           "5, factor 1.0",
+				inputN:         5,
+				inputMaxFactor: []float64{1.0},
+				expectedMin:    1, // jitter = [-5, 5], at least 1
+				expectedMax:    10,
+			},
+			{
+				name:           "positive",
+				inputN:         1,
+				inputMaxFactor: []float64{100.0},
+				expectedMin:    1, // jitter = [-100, 100], at least 1
+				expectedMax:    101,
+			},
+		}
+	)
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			wasDifferent := false
+			for i := 0; i < 1000; i++ {
+				// act
+				result := subject(test.inputN, test.inputMaxFactor...)
+
+				// assert
+				assertTrue(t, result >= test.expectedMin)
+				assertTrue(t, result <= test.expectedMax)
+
+				if result != test.inputN {
+					wasDifferent = true
+				}
+			}
+			assertTrue(t, wasDifferent)
+		})
+	}
+
+	t.Run("non-positive n", func(t *testing.T) {
+		for _, n := range [...]int{0, -1, -100} {
+			// act
+			result := subject(n)
+
+			// assert
+			assertEqual(t, 1, result)
+		}
+	})
+}
+
+func TestJitterIntJitterSource(t *testing.T) { // Note: not parallel, as it replaces the global jitter source.
+	// arrange
+	tests := [...]struct {
+		inputFloat64 float64
+		expectedN    int
+	}{
+		{inputFloat64: 0, expectedN: 5}, // 10 - 1 * 0.5 * 10
+		{inputFloat64: 0.5, expectedN: 10},
+		{inputFloat64: 0.75, expectedN: 13}, // 10 + 0.5 * 0.5 * 10 = 12.5, rounded
+	}
+
+	for _, test := range tests {
+		restore := xrand.SetJitterFloat64(func() float64 { return test.inputFloat64 })
+
+		// act
+		result := xrand.JitterInt(10, 0.5)
+
+		// assert
+		restore()
+		assertEqual(t, test.expectedN, result)
+	}
+}
+
+func TestPickDuration(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject = xrand.PickDuration
+		options = []time.Duration{5 * time.Minute, 10 * time.Minute, 15 * time.Minute}
+		factor  = 0.1
+		counts  = make(map[time.Duration]int, len(options))
+	)
+
+	for i := 0; i < 3000; i++ {
+		// act
+		result := subject(options, factor)
+
+		// assert
+		found := false
+		for _, option := range options {
+			jitter := time.Duration(factor * float64(option))
+			if result >= option-jitter && result < option+jitter {
+				counts[option]++
+				found = true
+
+				break
+			}
+		}
+		assertTrue(t, found)
+	}
+	for _, option := range options {
+		assertTrue(t, counts[option] > 0)
+	}
+}
+
+func TestJitterRandomFactor(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject = xrand.JitterRandomFactor
+		tests   = [...]struct {
+			name           string
+			inputDuration  time.Duration
+			inputMinFactor float64
+			inputMaxFactor float64
+			expectedMin    time.Duration
+			expectedMax    time.Duration
+		}{
+			{
+				name:           "2s, factor in [0.1, 0.5)",
+				inputDuration:  2 * time.Second,
+				inputMinFactor: 0.1,
+				inputMaxFactor: 0.5,
+				expectedMin:    time.Second, // jitter = [-1s, 1s)
+				expectedMax:    3 * time.Second,
+			},
+			{
+				name:           "5m, factor in [0, 0.2)",
+				inputDuration:  5 * time.Minute,
+				inputMinFactor: 0,
+				inputMaxFactor: 0.2,
+				expectedMin:    4 * time.Minute, // jitter = [-1m, 1m)
+				expectedMax:    6 * time.Minute,
+			},
+			{
+				name:           "100ms, negative min factor",
+				inputDuration:  100 * time.Millisecond,
+				inputMinFactor: -1,
+				inputMaxFactor: 0.3,
+				expectedMin:    70 * time.Millisecond, // jitter = [-30ms, 30ms)
+				expectedMax:    130 * time.Millisecond,
+			},
+			{
+				name:           "1m, max factor <= min factor",
+				inputDuration:  time.Minute,
+				inputMinFactor: 0.5,
+				inputMaxFactor: 0.1,
+				expectedMin:    30 * time.Second, // jitter = [-30s, 30s)
+				expectedMax:    90 * time.Second,
+			},
+			{
+				name:           "positive",
+				inputDuration:  time.Nanosecond,
+				inputMinFactor: 10,
+				inputMaxFactor: 100,
+				expectedMin:    1, // jitter = [-100ns, 100ns)
+				expectedMax:    101,
+			},
+		}
+	)
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			wasDifferent := false
+			for i := 0; i < 1000; i++ {
+				// act
+				result := subject(test.inputDuration, test.inputMinFactor, test.inputMaxFactor)
+
+				// assert
+				assertTrue(t, result > 0)
+				assertTrue(t, result >= test.expectedMin)
+				assertTrue(t, result < test.expectedMax)
+
+				if result != test.inputDuration {
+					wasDifferent = true
+				}
+			}
+			assertTrue(t, wasDifferent)
+		})
+	}
+}
+
+func TestJitterRandomFactorJitterSource(t *testing.T) { // Note: not parallel, as it replaces the global jitter source.
+	// arrange
+	tests := [...]struct {
+		inputFloat64     float64
+		expectedDuration time.Duration
+	}{
+		{inputFloat64: 0, expectedDuration: time.Second},                   // factor = 0
+		{inputFloat64: 0.25, expectedDuration: 937500 * time.Microsecond},  // factor = 0.125, 1s - 0.5 * 0.125 * 1s
+		{inputFloat64: 0.75, expectedDuration: 1187500 * time.Microsecond}, // factor = 0.375, 1s + 0.5 * 0.375 * 1s
+	}
+
+	for _, test := range tests {
+		restore := xrand.SetJitterFloat64(func() float64 { return test.inputFloat64 })
+
+		// act
+		result := xrand.JitterRandomFactor(time.Second, 0, 0.5)
+
+		// assert
+		restore()
+		assertEqual(t, test.expectedDuration, result)
+	}
+}
+
+func TestJitterAll(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject   = xrand.JitterAll
+		durations = []time.Duration{time.Second, time.Second, 5 * time.Minute, 100 * time.Millisecond, time.Hour}
+		factor    = 0.5
+		original  = append([]time.Duration(nil), durations...)
+	)
+
+	for i := 0; i < 100; i++ {
+		// act
+		result := subject(durations, factor)
+
+		// assert
+		assertTrue(t, reflect.DeepEqual(original, durations))
+		if !assertEqual(t, len(durations), len(result)) {
+			return
+		}
+		for idx, duration := range durations {
+			jitter := time.Duration(factor * float64(duration))
+			assertTrue(t, result[idx] > 0)
+			assertTrue(t, result[idx] >= duration-jitter)
+			assertTrue(t, result[idx] < duration+jitter)
+			assertTrue(t, result[idx] != duration)
+		}
+		assertTrue(t, result[0] != result[1]) // independent factors
+	}
+
+	t.Run("empty durations", func(t *testing.T) {
+		// act
+		result := subject(nil)
+
+		// assert
+		assertEqual(t, 0, len(result))
+	})
+}
+
+func TestJitterPercent(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject = xrand.JitterPercent
+		tests   = [...]struct {
+			name          string
+			inputDuration time.Duration
+			inputPercent  float64
+			expectedMin   time.Duration
+			expectedMax   time.Duration
+		}{
+			{
+				name:          "20%, as Jitter(d, 0.2)",
+				inputDuration: 10 * time.Second,
+				inputPercent:  20,
+				expectedMin:   8 * time.Second, // jitter = [-2s, 2s)
+				expectedMax:   12 * time.Second,
+			},
+			{
+				name:          "5%",
+				inputDuration: 10 * time.Minute,
+				inputPercent:  5,
+				expectedMin:   time.Duration(9.5 * float64(time.Minute)), // jitter = [-30s, 30s)
+				expectedMax:   time.Duration(10.5 * float64(time.Minute)),
+			},
+			{
+				name:          "100%",
+				inputDuration: time.Second,
+				inputPercent:  100,
+				expectedMin:   1, // jitter = [-1s, 1s)
+				expectedMax:   2 * time.Second,
+			},
+			{
+				name:          "0% - default factor",
+				inputDuration: time.Second,
+				inputPercent:  0,
+				expectedMin:   800 * time.Millisecond, // jitter = [-200ms, 200ms)
+				expectedMax:   1200 * time.Millisecond,
+			},
+			{
+				name:          "negative percent - default factor",
+				inputDuration: time.Second,
+				inputPercent:  -20,
+				expectedMin:   800 * time.Millisecond, // jitter = [-200ms, 200ms)
+				expectedMax:   1200 * time.Millisecond,
+			},
+		}
+	)
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				wasDifferent      = false
+				wasAtLeastHalfMax = false // catches mistakenly using a lower factor
+				halfJitter        = (test.expectedMax - test.inputDuration) / 2
+			)
+			for i := 0; i < 1000; i++ {
+				// act
+				result := subject(test.inputDuration, test.inputPercent)
+
+				// assert
+				assertTrue(t, result >= test.expectedMin)
+				assertTrue(t, result < test.expectedMax)
+
+				if result != test.inputDuration {
+					wasDifferent = true
+				}
+				if result > test.inputDuration+halfJitter || result < test.inputDuration-halfJitter {
+					wasAtLeastHalfMax = true
+				}
+			}
+			assertTrue(t, wasDifferent)
+			assertTrue(t, wasAtLeastHalfMax)
+		})
+	}
+}
+
+func TestJitterMin(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject = xrand.JitterMin
+		tests   = [...]struct {
+			name           string
+			inputDuration  time.Duration
+			inputMin       time.Duration
+			inputMaxFactor []float64
+			expectedMin    time.Duration
+			expectedMax    time.Duration
+			expectedFixed  bool
+		}{
+			{
+				name:           "min within jitter band",
+				inputDuration:  100 * time.Millisecond,
+				inputMin:       90 * time.Millisecond,
+				inputMaxFactor: []float64{0.5},
+				expectedMin:    90 * time.Millisecond, // jitter = [-50ms, 50ms), cut at 90ms
+				expectedMax:    150 * time.Millisecond,
+			},
+			{
+				name:           "min below jitter band",
+				inputDuration:  2 * time.Second,
+				inputMin:       time.Millisecond,
+				inputMaxFactor: nil,
+				expectedMin:    time.Duration(1.6 * float64(time.Second)), // jitter = [-0.4s, 0.4s)
+				expectedMax:    time.Duration(2.4 * float64(time.Second)),
+			},
+			{
+				name:           "tiny duration, big factor",
+				inputDuration:  10 * time.Nanosecond,
+				inputMin:       5 * time.Nanosecond,
+				inputMaxFactor: []float64{10},
+				expectedMin:    5, // jitter = [-100ns, 100ns), cut at 5ns
+				expectedMax:    110,
+			},
+			{
+				name:           "min above jitter band",
+				inputDuration:  time.Second,
+				inputMin:       time.Minute,
+				inputMaxFactor: []float64{0.5},
+				expectedMin:    time.Minute,
+				expectedMax:    time.Minute + 1,
+				expectedFixed:  true,
+			},
+			{
+				name:           "min = 0, as Jitter",
+				inputDuration:  time.Nanosecond,
+				inputMin:       0,
+				inputMaxFactor: []float64{100},
+				expectedMin:    1, // jitter = [-100ns, 100ns)
+				expectedMax:    101,
+			},
+		}
+	)
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			wasDifferent := false
+			for i := 0; i < 1000; i++ {
+				// act
+				result := subject(test.inputDuration, test.inputMin, test.inputMaxFactor...)
+
+				// assert
+				assertTrue(t, result >= test.expectedMin)
+				assertTrue(t, result < test.expectedMax)
+
+				if result != test.expectedMin {
+					wasDifferent = true
+				}
+			}
+			assertEqual(t, !test.expectedFixed, wasDifferent)
+		})
+	}
+}
+
+func TestJitterMinJitterSource(t *testing.T) { // Note: not parallel, as it replaces the global jitter source.
+	// arrange
+	tests := [...]struct {
+		inputFloat64     float64
+		expectedDuration time.Duration
+	}{
+		{inputFloat64: 0, expectedDuration: 90 * time.Millisecond}, // band = [90ms, 150ms)
+		{inputFloat64: 0.5, expectedDuration: 120 * time.Millisecond},
+		{inputFloat64: 0.75, expectedDuration: 135 * time.Millisecond},
+	}
+
+	for _, test := range tests {
+		restore := xrand.SetJitterFloat64(func() float64 { return test.inputFloat64 })
+
+		// act
+		result := xrand.JitterMin(100*time.Millisecond, 90*time.Millisecond, 0.5)
+
+		// assert
+		restore()
+		assertEqual(t, test.expectedDuration, result)
+	}
+}
+
+func TestJitterSigned(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject = xrand.JitterSigned
+		tests   = [...]struct {
+			name           string
+			inputDuration  time.Duration
+			inputMaxFactor []float64
+			expectedMin    time.Duration
+			expectedMax    time.Duration
+		}{
+			{
+				name:           "negative duration",
+				inputDuration:  -10 * time.Second,
+				inputMaxFactor: []float64{0.5},
+				expectedMin:    -15 * time.Second, // jitter = [-5s, 5s]
+				expectedMax:    -5 * time.Second,
+			},
+			{
+				name:           "negative duration, default factor",
+				inputDuration:  -time.Second,
+				inputMaxFactor: nil,
+				expectedMin:    -1200 * time.Millisecond, // jitter = [-200ms, 200ms]
+				expectedMax:    -800 * time.Millisecond,
+			},
+			{
+				name:           "positive duration",
+				inputDuration:  time.Minute,
+				inputMaxFactor: []float64{0.1},
+				expectedMin:    54 * time.Second, // jitter = [-6s, 6s]
+				expectedMax:    66 * time.Second,
+			},
+			{
+				name:           "crossing sign",
+				inputDuration:  -time.Second,
+				inputMaxFactor: []float64{2},
+				expectedMin:    -3 * time.Second, // jitter = [-2s, 2s]
+				expectedMax:    time.Second,
+			},
+		}
+	)
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			const iterations = 10000
+			var (
+				below, above int
+				sum          float64
+			)
+			for i := 0; i < iterations; i++ {
+				// act
+				result := subject(test.inputDuration, test.inputMaxFactor...)
+
+				// assert
+				assertTrue(t, result >= test.expectedMin)
+				assertTrue(t, result <= test.expectedMax)
+
+				if result < test.inputDuration {
+					below++
+				} else if result > test.inputDuration {
+					above++
+				}
+				sum += float64(result - test.inputDuration)
+			}
+			// symmetric band around duration: about half the results on each side, mean jitter ~0.
+			assertTrue(t, below > iterations*45/100 && above > iterations*45/100)
+			halfBand := float64(test.expectedMax-test.expectedMin) / 2
+			assertTrue(t, math.Abs(sum/iterations) < halfBand*0.05)
+		})
+	}
+
+	t.Run("zero duration", func(t *testing.T) {
+		t.Parallel()
+
+		// act
+		result := subject(0, 0.5)
+
+		// assert
+		assertEqual(t, time.Duration(0), result)
+	})
+}
+
+func TestJitterSignedJitterSource(t *testing.T) { // Note: not parallel, as it replaces the global jitter source.
+	// arrange
+	tests := [...]struct {
+		inputFloat64     float64
+		expectedDuration time.Duration
+	}{
+		{inputFloat64: 0, expectedDuration: -500 * time.Millisecond}, // -1s - 1 * 0.5 * -1s
+		{inputFloat64: 0.5, expectedDuration: -time.Second},
+		{inputFloat64: 0.75, expectedDuration: -1250 * time.Millisecond}, // -1s + 0.5 * 0.5 * -1s
+	}
+
+	for _, test := range tests {
+		restore := xrand.SetJitterFloat64(func() float64 { return test.inputFloat64 })
+
+		// act
+		result := xrand.JitterSigned(-time.Second, 0.5)
+
+		// assert
+		restore()
+		assertEqual(t, test.expectedDuration, result)
+	}
+}
+
+func TestLoadAwareJitter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("higher concurrency, wider spread", testLoadAwareJitterSpread)
+	t.Run("result is positive", testLoadAwareJitterPositive)
+	t.Run("panics for non-positive base", testLoadAwareJitterPanics)
+}
+
+func testLoadAwareJitterSpread(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		base  = time.Second
+		tests = [...]struct {
+			concurrency    int
+			expectedFactor float64
+		}{
+			{concurrency: -5, expectedFactor: 0.2},
+			{concurrency: 1, expectedFactor: 0.2},
+			{concurrency: 4, expectedFactor: 0.4},
+			{concurrency: 32, expectedFactor: 0.7},
+			{concurrency: 128, expectedFactor: 0.9},
+			{concurrency: 100000, expectedFactor: 0.9},
+		}
+		prevSpread time.Duration
+		prevFactor float64
+	)
+
+	for _, test := range tests {
+		var (
+			minResult = time.Duration(math.MaxInt64)
+			maxResult time.Duration
+			lower     = time.Duration(float64(base) * (1 - test.expectedFactor))
+			upper     = time.Duration(float64(base) * (1 + test.expectedFactor))
+		)
+		for i := 0; i < 5000; i++ {
+			// act
+			result := xrand.LoadAwareJitter(base, test.concurrency)
+
+			// assert
+			assertTrue(t, result >= lower)
+			assertTrue(t, result < upper)
+			if result < minResult {
+				minResult = result
+			}
+			if result > maxResult {
+				maxResult = result
+			}
+		}
+		spread := maxResult - minResult
+		// spread is close to the full band.
+		assertTrue(t, float64(spread) > 0.95*float64(upper-lower))
+		if test.expectedFactor > prevFactor {
+			assertTrue(t, spread > prevSpread)
+		}
+		prevSpread, prevFactor = spread, test.expectedFactor
+	}
+}
+
+func testLoadAwareJitterPositive(t *testing.T) {
+	t.Parallel()
+
+	for _, concurrency := range [...]int{1, 1000, math.MaxInt} {
+		for _, base := range [...]time.Duration{1, 2, time.Millisecond} {
+			for i := 0; i < 1000; i++ {
+				// act
+				result := xrand.LoadAwareJitter(base, concurrency)
+
+				// assert
+				assertTrue(t, result > 0)
+			}
+		}
+	}
+}
+
+func testLoadAwareJitterPanics(t *testing.T) {
+	t.Parallel()
+
+	// act & assert
+	assertPanics(t, func() { _ = xrand.LoadAwareJitter(0, 10) })
+	assertPanics(t, func() { _ = xrand.LoadAwareJitter(-time.Second, 10) })
+}
+
+func TestJitterDeadline(t *testing.T) { // Note: not parallel, as it replaces the global clock.
+	// arrange
+	var (
+		fixedNow = time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+		base     = time.Minute
+		tests    = [...]struct {
+			name           string
+			inputFactor    []float64
+			expectedFactor float64
+		}{
+			{name: "default factor", inputFactor: nil, expectedFactor: 0.2},
+			{name: "custom factor", inputFactor: []float64{0.5}, expectedFactor: 0.5},
+		}
+	)
+	defer xrand.SetNow(func() time.Time { return fixedNow })()
+
+	for _, test := range tests {
+		var (
+			lower = fixedNow.Add(time.Duration(float64(base) * (1 - test.expectedFactor)))
+			upper = fixedNow.Add(time.Duration(float64(base) * (1 + test.expectedFactor)))
+		)
+		for i := 0; i < 1000; i++ {
+			// act
+			result := xrand.JitterDeadline(base, test.inputFactor...)
+
+			// assert
+			assertTrue(t, result.After(fixedNow))
+			assertTrue(t, !result.Before(lower))
+			assertTrue(t, result.Before(upper))
+		}
+	}
+}
+
+func TestJitterSeconds(t *testing.T) {
+	t.Parallel()
+
+	t.Run("result is within the band", testJitterSecondsBand)
+	t.Run("result is positive", testJitterSecondsPositive)
+	t.Run("panics for invalid seconds", testJitterSecondsPanics)
+}
+
+func testJitterSecondsBand(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	tests := [...]struct {
+		name           string
+		inputSeconds   float64
+		inputFactor    []float64
+		expectedFactor float64
+	}{
+		{name: "default factor", inputSeconds: 30, inputFactor: nil, expectedFactor: 0.2},
+		{name: "custom factor", inputSeconds: 0.25, inputFactor: []float64{0.5}, expectedFactor: 0.5},
+		{name: "non-positive factor, default", inputSeconds: 2, inputFactor: []float64{-1}, expectedFactor: 0.2},
+	}
+
+	for _, test := range tests {
+		var (
+			lower      = test.inputSeconds * (1 - test.expectedFactor)
+			upper      = test.inputSeconds * (1 + test.expectedFactor)
+			minResult  = math.Inf(1)
+			maxResult  = math.Inf(-1)
+			aboveCount int
+		)
+		for i := 0; i < 5000; i++ {
+			// act
+			result := xrand.JitterSeconds(test.inputSeconds, test.inputFactor...)
+
+			// assert
+			assertTrue(t, result >= lower)
+			assertTrue(t, result < upper)
+			minResult = math.Min(minResult, result)
+			maxResult = math.Max(maxResult, result)
+			if result > test.inputSeconds {
+				aboveCount++
+			}
+		}
+		assertTrue(t, maxResult-minResult > 0.95*(upper-lower)) // spread is close to the full band
+		assertTrue(t, aboveCount > 2250 && aboveCount < 2750)   // symmetric
+	}
+}
+
+func testJitterSecondsPositive(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 1000; i++ {
+		// act
+		result := xrand.JitterSeconds(1e-9, 1.5)
+
+		// assert
+		assertTrue(t, result > 0)
+	}
+}
+
+func testJitterSecondsPanics(t *testing.T) {
+	t.Parallel()
+
+	// act & assert
+	assertPanics(t, func() { _ = xrand.JitterSeconds(0) })
+	assertPanics(t, func() { _ = xrand.JitterSeconds(-1) })
+	assertPanics(t, func() { _ = xrand.JitterSeconds(math.NaN()) })
+	assertPanics(t, func() { _ = xrand.JitterSeconds(math.Inf(1)) })
+}
+
+func TestJitterSecondsMatchesJitter(t *testing.T) { // Note: not parallel, as it replaces the global jitter source.
+	for _, value := range [...]float64{0, 0.1, 0.5, 0.75, 0.999} {
+		restore := xrand.SetJitterFloat64(func() float64 { return value })
+
+		// act
+		result := xrand.JitterSeconds(1.5, 0.4)
+		expected := xrand.Jitter(1500*time.Millisecond, 0.4).Seconds()
+
+		// assert
+		restore()
+		assertTrue(t, math.Abs(expected-result) < 1e-9)
+	}
+}
+
+func TestJitterTimeOfDay(t *testing.T) {
+	t.Parallel()
+
+	t.Run("result is within the day and the spread", testJitterTimeOfDayRange)
+	t.Run("spread is symmetric", testJitterTimeOfDaySymmetric)
+	t.Run("no spread", testJitterTimeOfDayNoSpread)
+}
+
+func testJitterTimeOfDayRange(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const day = 24 * time.Hour
+	tests := [...]struct {
+		name        string
+		inputBase   time.Duration
+		inputSpread time.Duration
+	}{
+		{name: "middle of the day", inputBase: 12 * time.Hour, inputSpread: time.Hour},
+		{name: "wraps after midnight", inputBase: 23*time.Hour + 50*time.Minute, inputSpread: 30 * time.Minute},
+		{name: "wraps before midnight", inputBase: 10 * time.Minute, inputSpread: 30 * time.Minute},
+		{name: "base beyond the day", inputBase: 27 * time.Hour, inputSpread: time.Hour},
+		{name: "negative base", inputBase: -time.Hour, inputSpread: time.Hour},
+		{name: "spread above half a day", inputBase: 6 * time.Hour, inputSpread: 100 * time.Hour},
+	}
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			maxDistance := test.inputSpread
+			if maxDistance > day/2 {
+				maxDistance = day / 2
+			}
+			base := ((test.inputBase % day) + day) % day
+			for i := 0; i < 1000; i++ {
+				// act
+				result := xrand.JitterTimeOfDay(test.inputBase, test.inputSpread)
+
+				// assert
+				assertTrue(t, result >= 0 && result < day)
+				distance := result - base // circular distance, within the day
+				if distance < 0 {
+					distance = -distance
+				}
+				if distance > day/2 {
+					distance = day - distance
+				}
+				assertTrue(t, distance <= maxDistance)
+			}
+		})
+	}
+}
+
+func testJitterTimeOfDaySymmetric(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const (
+		iterations = 50000
+		day        = 24 * time.Hour
+	)
+	var (
+		base   = 5 * time.Minute // Note: close to midnight, half of the results are wrapped.
+		spread = 10 * time.Minute
+		counts = make([]int, 4) // [-10m,-5m), [-5m,0), [0,5m), [5m,10m)
+	)
+
+	for i := 0; i < iterations; i++ {
+		// act
+		result := xrand.JitterTimeOfDay(base, spread)
+
+		// assert
+		offset := result - base
+		if offset >= day/2 {
+			offset -= day
+		}
+		if !assertTrue(t, offset >= -spread && offset < spread) {
+			return
+		}
+		counts[(offset+spread)/(spread/2)]++
+	}
+	assertUniform(t, counts, iterations, 0.05)
+}
+
+func testJitterTimeOfDayNoSpread(t *testing.T) {
+	t.Parallel()
+
+	// act & assert
+	assertEqual(t, 3*time.Hour, xrand.JitterTimeOfDay(3*time.Hour, 0))
+	assertEqual(t, 3*time.Hour, xrand.JitterTimeOfDay(3*time.Hour, -time.Minute))
+	assertEqual(t, 3*time.Hour, xrand.JitterTimeOfDay(27*time.Hour, 0))
+	assertEqual(t, 23*time.Hour, xrand.JitterTimeOfDay(-time.Hour, 0))
+}
+
+func BenchmarkJitterInt(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = xrand.JitterInt(500)
+	}
+}
+
+func ExampleJitterInt() {
+	// slightly alter +/- a batch size
+	batchSize := 500
+	factor := 0.1
+	jitteredBatchSize := xrand.JitterInt(batchSize, factor)
+	fmt.Println(jitteredBatchSize)
+}
+
+func ExamplePickDuration() {
+	// pick a cache TTL from a quantized policy, slightly altered +/-.
+	ttl := xrand.PickDuration([]time.Duration{5 * time.Minute, 10 * time.Minute, 15 * time.Minute})
+	fmt.Println(ttl)
+}
+
+func ExampleJitterRandomFactor() {
+	// alter +/- a poll interval with a factor which itself varies in [0.05, 0.25).
+	pollInterval := 30 * time.Second
+	jitteredPollInterval := xrand.JitterRandomFactor(pollInterval, 0.05, 0.25)
+	fmt.Println(jitteredPollInterval)
+}
+
+func ExampleJitterAll() {
+	// slightly alter +/- a batch of scheduler intervals, each independently.
+	intervals := []time.Duration{time.Minute, 5 * time.Minute, time.Hour}
+	jitteredIntervals := xrand.JitterAll(intervals, 0.1)
+	fmt.Println(jitteredIntervals)
+}
+
+func ExampleJitterPercent() {
+	// slightly alter +/- 20% a time.Duration, as read from a config.
+	cacheTTL := 10 * time.Minute
+	jitterPercent := 20.0
+	jitteredCacheTTL := xrand.JitterPercent(cacheTTL, jitterPercent)
+	fmt.Println(jitteredCacheTTL)
+}
+
+func ExampleJitterMin() {
+	// slightly alter +/- a tiny backoff, but never below 1ms.
+	backoff := xrand.JitterMin(2*time.Millisecond, time.Millisecond, 0.8)
+	fmt.Println(backoff)
+}
+
+func ExampleJitterSigned() {
+	// slightly alter +/- 10% a clock-skew correction, which may be negative.
+	skewCorrection := -250 * time.Millisecond
+	jitteredCorrection := xrand.JitterSigned(skewCorrection, 0.1)
+	fmt.Println(jitteredCorrection)
+}
+
+func ExampleLoadAwareJitter() {
+	// spread the retries of 50 concurrent workers more than Jitter's default would.
+	const workers = 50
+	delay := xrand.LoadAwareJitter(time.Second, workers)
+	fmt.Println(delay)
+}
+
+func ExampleJitterDeadline() {
+	// process a batch until a jittered deadline, to avoid all workers stopping at once.
+	ctx, cancel := context.WithDeadline(context.Background(), xrand.JitterDeadline(time.Minute))
+	defer cancel()
+
+	deadline, _ := ctx.Deadline()
+	fmt.Println(time.Until(deadline) > 0)
+	// Output: true
+}
+
+func ExampleJitterSeconds() {
+	// a retry delay, for a system expressing durations in seconds.
+	delay := xrand.JitterSeconds(2.5, 0.1)
+	fmt.Printf("%.3fs\n", delay) // like 2.413s
+}
+
+func ExampleJitterTimeOfDay() {
+	// run the nightly backup at 02:00, +/- 30 minutes, differently on each server.
+	startAt := xrand.JitterTimeOfDay(2*time.Hour, 30*time.Minute)
+	midnight := time.Now().Truncate(24 * time.Hour)
+	fmt.Println(midnight.Add(startAt).Format("15:04")) // like 01:47
+}
+
+func TestJitterTimeOfDayJitterSource(t *testing.T) { // Note: not parallel, as it replaces the global jitter source.
+	// arrange
+	tests := [...]struct {
+		inputFloat64 float64
+		expectedTime time.Duration
+	}{
+		{inputFloat64: 0, expectedTime: 23*time.Hour + 20*time.Minute}, // 23:50 - 30m
+		{inputFloat64: 0.5, expectedTime: 23*time.Hour + 50*time.Minute},
+		{inputFloat64: 0.75, expectedTime: 5 * time.Minute}, // 23:50 + 15m, wrapped
+	}
+
+	for _, test := range tests {
+		restore := xrand.SetJitterFloat64(func() float64 { return test.inputFloat64 })
+
+		// act
+		result := xrand.JitterTimeOfDay(23*time.Hour+50*time.Minute, 30*time.Minute)
+
+		// assert
+		restore()
+		assertEqual(t, test.expectedTime, result)
+	}
+}
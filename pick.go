@@ -0,0 +1,208 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrAllExcluded is returned when there is no value left to pick from, after exclusion.
+	ErrAllExcluded = errors.New("xrand: all values are excluded")
+	// ErrNoValidItem is returned when no item satisfies the validation.
+	ErrNoValidItem = errors.New("xrand: no item satisfies the validation")
+)
+
+// maxPickValidatedAttempts is the max no. of random picks [PickValidated] tries.
+const maxPickValidatedAttempts = 32
+
+// PickEnum returns a random value from the given enum-like values.
+// It is the idiomatic way of picking a random state / kind / type, defined as:
+//
+//	type State int
+//
+//	const (
+//		StateIdle State = iota
+//		StateRunning
+//		StateStopped
+//	)
+//
+// It panics if values is empty.
+func PickEnum[T ~int](values []T) T {
+	return values[globalRand.Intn(len(values))]
+}
+
+// PickEnumExcluding returns a random value from the given enum-like values, different from excluded one.
+// It is useful for state machines that must transition into a different state than the current one.
+// Values different from excluded have the same probability of being picked.
+// It returns [ErrAllExcluded] if every value is equal to excluded one.
+// It panics if values is empty.
+func PickEnumExcluding[T ~int](values []T, excluded T) (T, error) {
+	if len(values) == 0 {
+		panic("invalid argument to PickEnumExcluding")
+	}
+
+	candidates := 0
+	for _, value := range values {
+		if value != excluded {
+			candidates++
+		}
+	}
+	if candidates == 0 {
+		return excluded, ErrAllExcluded
+	}
+
+	// pick the idx-th value different from excluded one.
+	idx := globalRand.Intn(candidates)
+	for _, value := range values {
+		if value == excluded {
+			continue
+		}
+		if idx == 0 {
+			return value, nil
+		}
+		idx--
+	}
+
+	return excluded, ErrAllExcluded // never reached, as candidates > 0
+}
+
+// PickEither returns a with probability pA, and b otherwise (with probability 1-pA).
+// pA is clamped to [0.0, 1.0], meaning pA <= 0 always returns b, while pA >= 1 always returns a.
+// It is useful for feature flags, A/B testing, etc.
+func PickEither[T any](a, b T, pA float64) T {
+	if Float64() < pA { // Float64 is in [0.0, 1.0), which implicitly clamps pA.
+		return a
+	}
+
+	return b
+}
+
+// StreamPick returns a channel which emits random elements from items, until ctx is cancelled.
+// Upon cancellation, the channel is closed and the underlying goroutine ends.
+// Receiving from the channel concurrently is safe.
+// It panics if items is empty.
+func StreamPick[T any](ctx context.Context, items []T) <-chan T {
+	if len(items) == 0 {
+		panic("invalid argument to StreamPick")
+	}
+
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ch <- items[globalRand.Intn(len(items))]:
+			}
+		}
+	}()
+
+	return ch
+}
+
+// PickIndexed returns a random element from items, together with its index.
+// It panics if items is empty.
+func PickIndexed[T any](items []T) (int, T) {
+	idx := globalRand.Intn(len(items))
+
+	return idx, items[idx]
+}
+
+// OneOf returns a random element from the given options, each being equally likely,
+// handy for inline choices, like xrand.OneOf("red", "green", "blue").
+// It panics if no option is provided.
+func OneOf[T any](options ...T) T {
+	if len(options) == 0 {
+		panic("invalid argument to OneOf")
+	}
+
+	return options[globalRand.Intn(len(options))]
+}
+
+// PickSyncMapKey returns a random key from the sync.Map, or false if the map is empty.
+// As sync.Map has no length, keys are visited in a single pass, using reservoir sampling
+// (the i-th visited key replaces the picked one with probability 1/i), which needs no extra memory
+// and keeps each visited key equally likely, even under concurrent modification of the map.
+func PickSyncMapKey(m *sync.Map) (any, bool) {
+	var (
+		picked any
+		seen   int
+	)
+	m.Range(func(key, _ any) bool {
+		seen++
+		if globalRand.Intn(seen) == 0 {
+			picked = key
+		}
+
+		return true
+	})
+
+	return picked, seen > 0
+}
+
+// PickWhere returns a random element from items which satisfies the predicate,
+// or false if no element matches.
+// Items are visited in a single pass, using reservoir sampling, without allocating
+// a filtered copy, while each matching element has the same probability of being picked.
+func PickWhere[T any](items []T, pred func(T) bool) (T, bool) {
+	var (
+		picked  T
+		matches int
+	)
+	for _, item := range items {
+		if !pred(item) {
+			continue
+		}
+		matches++
+		if globalRand.Intn(matches) == 0 {
+			picked = item
+		}
+	}
+
+	return picked, matches > 0
+}
+
+// PickExcluding returns a random element from items which is not in the blocklist,
+// or false if all elements are blocked.
+// It is useful, for example, to pick a shard while avoiding the known-bad ones.
+// Like [PickWhere], items are visited in a single pass, without allocating a filtered copy,
+// while each allowed element has the same probability of being picked.
+func PickExcluding[T comparable](items []T, blocklist map[T]struct{}) (T, bool) {
+	return PickWhere(items, func(item T) bool {
+		_, blocked := blocklist[item]
+
+		return !blocked
+	})
+}
+
+// PickValidated returns a random element from items which satisfies valid, like a valid enum value
+// for config validation fixtures. Each valid element has the same probability of being picked.
+// Elements are picked at random, until a valid one is found, for at most 32 attempts,
+// which is fast when most elements are valid, without allocating.
+// When all the attempts fail, items are visited in a single pass, like in [PickWhere],
+// so that a rare valid element is still found, and an infinite loop is avoided when nothing is valid.
+// Thus, valid is called at most 32 + len(items) times.
+// It returns [ErrNoValidItem] if no element is valid, or items is empty.
+func PickValidated[T comparable](items []T, valid func(T) bool) (T, error) {
+	if len(items) > 0 {
+		for attempt := 0; attempt < maxPickValidatedAttempts; attempt++ {
+			if item := items[globalRand.Intn(len(items))]; valid(item) {
+				return item, nil
+			}
+		}
+	}
+	if item, found := PickWhere(items, valid); found {
+		return item, nil
+	}
+
+	var zero T
+
+	return zero, ErrNoValidItem
+}
@@ -0,0 +1,665 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/actforgood/xrand"
+)
+
+type testState int
+
+const (
+	testStateIdle testState = iota
+	testStateRunning
+	testStatePaused
+	testStateStopped
+)
+
+var testStates = []testState{testStateIdle, testStateRunning, testStatePaused, testStateStopped}
+
+func TestPickEnum(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 20000
+	var (
+		subject = xrand.PickEnum[testState]
+		counts  = make([]int, len(testStates))
+	)
+
+	for i := 0; i < iterations; i++ {
+		// act
+		result := subject(testStates)
+
+		// assert
+		assertTrue(t, result >= testStateIdle && result <= testStateStopped)
+		counts[result]++
+	}
+	assertUniform(t, counts, iterations, 0.1)
+}
+
+func TestPickEnumExcluding(t *testing.T) {
+	t.Parallel()
+
+	t.Run("excluded value is never returned, others are uniform", testPickEnumExcludingExclusion)
+	t.Run("error when all values are excluded", testPickEnumExcludingAllExcluded)
+	t.Run("panics for empty values", testPickEnumExcludingPanics)
+}
+
+func testPickEnumExcludingExclusion(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 20000
+	var (
+		subject  = xrand.PickEnumExcluding[testState]
+		excluded = testStateRunning
+		counts   = make(map[testState]int, len(testStates))
+	)
+
+	for i := 0; i < iterations; i++ {
+		// act
+		result, err := subject(testStates, excluded)
+
+		// assert
+		assertNil(t, err)
+		assertTrue(t, result != excluded)
+		counts[result]++
+	}
+	assertEqual(t, 0, counts[excluded])
+	assertUniform(
+		t,
+		[]int{counts[testStateIdle], counts[testStatePaused], counts[testStateStopped]},
+		iterations,
+		0.1,
+	)
+}
+
+func testPickEnumExcludingAllExcluded(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	subject := xrand.PickEnumExcluding[testState]
+
+	// act
+	result, err := subject([]testState{testStatePaused, testStatePaused}, testStatePaused)
+
+	// assert
+	assertTrue(t, errors.Is(err, xrand.ErrAllExcluded))
+	assertEqual(t, testStatePaused, result)
+}
+
+func testPickEnumExcludingPanics(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	subject := xrand.PickEnumExcluding[testState]
+
+	// act & assert
+	assertPanics(t, func() {
+		_, _ = subject(nil, testStateIdle)
+	})
+}
+
+func TestPickEither(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 50000
+	var (
+		subject = xrand.PickEither[string]
+		tests   = [...]struct {
+			name         string
+			inputPA      float64
+			expectedRate float64
+		}{
+			{name: "pA = 0.25", inputPA: 0.25, expectedRate: 0.25},
+			{name: "pA = 0.5", inputPA: 0.5, expectedRate: 0.5},
+			{name: "pA = 0.9", inputPA: 0.9, expectedRate: 0.9},
+			{name: "pA = 0", inputPA: 0, expectedRate: 0},
+			{name: "pA < 0", inputPA: -1, expectedRate: 0},
+			{name: "pA = 1", inputPA: 1, expectedRate: 1},
+			{name: "pA > 1", inputPA: 2.5, expectedRate: 1},
+		}
+	)
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			countA := 0
+			for i := 0; i < iterations; i++ {
+				// act
+				result := subject("a", "b", test.inputPA)
+
+				// assert
+				assertTrue(t, result == "a" || result == "b")
+				if result == "a" {
+					countA++
+				}
+			}
+			rateA := float64(countA) / iterations
+			if test.expectedRate == 0 || test.expectedRate == 1 {
+				assertEqual(t, test.expectedRate, rateA)
+			} else {
+				assertTrue(t, math.Abs(test.expectedRate-rateA) < 0.01)
+			}
+		})
+	}
+}
+
+func TestStreamPick(t *testing.T) {
+	t.Parallel()
+
+	t.Run("emits only given items", testStreamPickEmitsItems)
+	t.Run("cancellation closes the channel", testStreamPickCancellation)
+	t.Run("panics for empty items", testStreamPickPanics)
+}
+
+func testStreamPickEmitsItems(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		items       = []string{"a", "b", "c"}
+		ctx, cancel = context.WithCancel(context.Background())
+		counts      = make(map[string]int, len(items))
+	)
+	defer cancel()
+
+	// act
+	ch := xrand.StreamPick(ctx, items)
+
+	// assert
+	for i := 0; i < 3000; i++ {
+		counts[<-ch]++
+	}
+	assertEqual(t, len(items), len(counts))
+	for _, item := range items {
+		assertTrue(t, counts[item] > 0)
+	}
+}
+
+func testStreamPickCancellation(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := xrand.StreamPick(ctx, []int{1, 2, 3})
+	<-ch
+
+	// act
+	cancel()
+
+	// assert
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("channel was not closed after context cancellation")
+		}
+	}
+}
+
+func testStreamPickPanics(t *testing.T) {
+	t.Parallel()
+
+	// act & assert
+	assertPanics(t, func() {
+		_ = xrand.StreamPick(context.Background(), []int{})
+	})
+}
+
+func TestStreamPickDoesNotLeakGoroutines(t *testing.T) { // Note: not parallel, to have a stable no. of goroutines.
+	// arrange
+	goroutinesBefore := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	channels := make([]<-chan int, 10)
+	for i := range channels {
+		channels[i] = xrand.StreamPick(ctx, []int{1, 2, 3})
+	}
+	assertTrue(t, runtime.NumGoroutine() >= goroutinesBefore+len(channels))
+
+	// act
+	cancel()
+
+	// assert
+	for _, ch := range channels {
+		for range ch { // drain until closed
+		}
+	}
+	for i := 0; i < 100 && runtime.NumGoroutine() > goroutinesBefore; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assertTrue(t, runtime.NumGoroutine() <= goroutinesBefore)
+}
+
+func TestPickIndexed(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject = xrand.PickIndexed[string]
+		items   = []string{"a", "b", "c", "d", "e"}
+		counts  = make([]int, len(items))
+	)
+
+	for i := 0; i < 5000; i++ {
+		// act
+		idx, item := subject(items)
+
+		// assert
+		if assertTrue(t, idx >= 0 && idx < len(items)) {
+			assertEqual(t, items[idx], item)
+			counts[idx]++
+		}
+	}
+	for _, count := range counts {
+		assertTrue(t, count > 0)
+	}
+
+	t.Run("panics for empty items", func(t *testing.T) {
+		assertPanics(t, func() {
+			_, _ = subject(nil)
+		})
+	})
+}
+
+func TestOneOf(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 30000
+	var (
+		options = map[string]int{"red": 0, "green": 1, "blue": 2}
+		counts  = make([]int, len(options))
+	)
+
+	for i := 0; i < iterations; i++ {
+		// act
+		result := xrand.OneOf("red", "green", "blue")
+
+		// assert
+		idx, found := options[result]
+		if assertTrue(t, found) {
+			counts[idx]++
+		}
+	}
+	assertUniform(t, counts, iterations, 0.05)
+
+	t.Run("single option", func(t *testing.T) {
+		assertEqual(t, 7, xrand.OneOf(7))
+	})
+
+	t.Run("panics for no options", func(t *testing.T) {
+		assertPanics(t, func() {
+			_ = xrand.OneOf[int]()
+		})
+	})
+}
+
+func TestPickSyncMapKey(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returned key exists, keys are uniform", testPickSyncMapKeyUniform)
+	t.Run("empty map", testPickSyncMapKeyEmpty)
+}
+
+func testPickSyncMapKeyUniform(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 20000
+	var (
+		subject = xrand.PickSyncMapKey
+		m       sync.Map
+		counts  = make([]int, 4)
+	)
+	for i := 0; i < len(counts); i++ {
+		m.Store(i, fmt.Sprintf("value%d", i))
+	}
+
+	for i := 0; i < iterations; i++ {
+		// act
+		key, ok := subject(&m)
+
+		// assert
+		if assertTrue(t, ok) {
+			_, exists := m.Load(key)
+			assertTrue(t, exists)
+			counts[key.(int)]++
+		}
+	}
+	assertUniform(t, counts, iterations, 0.1)
+}
+
+func testPickSyncMapKeyEmpty(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var m sync.Map
+
+	// act
+	key, ok := xrand.PickSyncMapKey(&m)
+
+	// assert
+	assertTrue(t, !ok)
+	assertNil(t, key)
+}
+
+func TestPickWhere(t *testing.T) {
+	t.Parallel()
+
+	t.Run("only matching elements, uniformly", testPickWhereUniform)
+	t.Run("no match", testPickWhereNoMatch)
+}
+
+func testPickWhereUniform(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 30000
+	var (
+		subject = xrand.PickWhere[int]
+		items   = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+		isEven  = func(n int) bool { return n%2 == 0 }
+		counts  = make(map[int]int, len(items))
+	)
+
+	for i := 0; i < iterations; i++ {
+		// act
+		result, ok := subject(items, isEven)
+
+		// assert
+		assertTrue(t, ok)
+		assertTrue(t, isEven(result))
+		counts[result]++
+	}
+	assertEqual(t, 5, len(counts))
+	assertUniform(t, []int{counts[2], counts[4], counts[6], counts[8], counts[10]}, iterations, 0.1)
+}
+
+func testPickWhereNoMatch(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	subject := xrand.PickWhere[string]
+	isEmpty := func(s string) bool { return s == "" }
+
+	// act
+	result1, ok1 := subject([]string{"a", "b"}, isEmpty)
+	result2, ok2 := subject(nil, isEmpty)
+
+	// assert
+	assertTrue(t, !ok1)
+	assertEqual(t, "", result1)
+	assertTrue(t, !ok2)
+	assertEqual(t, "", result2)
+}
+
+func TestPickExcluding(t *testing.T) {
+	t.Parallel()
+
+	t.Run("only allowed elements, uniformly", testPickExcludingUniform)
+	t.Run("all blocked", testPickExcludingAllBlocked)
+}
+
+func testPickExcludingUniform(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 30000
+	var (
+		subject   = xrand.PickExcluding[string]
+		shards    = []string{"shard1", "shard2", "shard3", "shard4", "shard5"}
+		blocklist = map[string]struct{}{"shard2": {}, "shard5": {}, "shard9": {}}
+		counts    = make(map[string]int, len(shards))
+	)
+
+	for i := 0; i < iterations; i++ {
+		// act
+		result, ok := subject(shards, blocklist)
+
+		// assert
+		assertTrue(t, ok)
+		_, blocked := blocklist[result]
+		assertTrue(t, !blocked)
+		counts[result]++
+	}
+	assertEqual(t, 3, len(counts))
+	assertUniform(t, []int{counts["shard1"], counts["shard3"], counts["shard4"]}, iterations, 0.1)
+
+	t.Run("nil blocklist", func(t *testing.T) {
+		result, ok := subject(shards, nil)
+
+		assertTrue(t, ok)
+		assertTrue(t, strings.HasPrefix(result, "shard"))
+	})
+}
+
+func testPickExcludingAllBlocked(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject   = xrand.PickExcluding[int]
+		blocklist = map[int]struct{}{1: {}, 2: {}}
+	)
+
+	// act
+	result1, ok1 := subject([]int{1, 2, 1}, blocklist)
+	result2, ok2 := subject(nil, blocklist)
+
+	// assert
+	assertTrue(t, !ok1)
+	assertEqual(t, 0, result1)
+	assertTrue(t, !ok2)
+	assertEqual(t, 0, result2)
+}
+
+func TestPickValidated(t *testing.T) {
+	t.Parallel()
+
+	t.Run("only valid elements, uniformly", testPickValidatedUniform)
+	t.Run("rare valid element is found", testPickValidatedRare)
+	t.Run("error when nothing is valid", testPickValidatedNoneValid)
+}
+
+func testPickValidatedUniform(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 30000
+	var (
+		items  = []int{1, 2, 3, 4, 5, 6, 7, 8}
+		isEven = func(n int) bool { return n%2 == 0 }
+		counts = make(map[int]int, len(items))
+	)
+
+	for i := 0; i < iterations; i++ {
+		// act
+		result, err := xrand.PickValidated(items, isEven)
+
+		// assert
+		assertNil(t, err)
+		assertTrue(t, isEven(result))
+		counts[result]++
+	}
+	assertEqual(t, 4, len(counts))
+	assertUniform(t, []int{counts[2], counts[4], counts[6], counts[8]}, iterations, 0.05)
+}
+
+func testPickValidatedRare(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	items := make([]int, 10000)
+	items[1234] = 1
+
+	for i := 0; i < 10; i++ {
+		// act
+		result, err := xrand.PickValidated(items, func(n int) bool { return n == 1 })
+
+		// assert
+		assertNil(t, err)
+		assertEqual(t, 1, result)
+	}
+}
+
+func testPickValidatedNoneValid(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		items = []string{"a", "b", "c"}
+		calls int
+		never = func(string) bool {
+			calls++
+
+			return false
+		}
+	)
+
+	// act
+	result, err := xrand.PickValidated(items, never)
+
+	// assert
+	assertTrue(t, errors.Is(err, xrand.ErrNoValidItem))
+	assertEqual(t, "", result)
+	assertEqual(t, xrand.MaxPickValidatedAttempts+len(items), calls) // bounded
+
+	// act
+	calls = 0
+	result, err = xrand.PickValidated(nil, never)
+
+	// assert
+	assertTrue(t, errors.Is(err, xrand.ErrNoValidItem))
+	assertEqual(t, "", result)
+	assertEqual(t, 0, calls)
+}
+
+func ExamplePickEnum() {
+	type Color int
+	const (
+		Red Color = iota
+		Green
+		Blue
+	)
+
+	// pick a random color
+	color := xrand.PickEnum([]Color{Red, Green, Blue})
+	fmt.Println(color)
+}
+
+func ExamplePickEnumExcluding() {
+	type State int
+	const (
+		Idle State = iota
+		Running
+		Stopped
+	)
+
+	// transition into a random, different state than current one
+	currentState := Running
+	nextState, err := xrand.PickEnumExcluding([]State{Idle, Running, Stopped}, currentState)
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+	fmt.Println(nextState)
+}
+
+func ExamplePickEither() {
+	// enable a new feature for ~10% of the requests.
+	handler := xrand.PickEither("new-handler", "old-handler", 0.1)
+	fmt.Println(handler)
+}
+
+func ExampleStreamPick() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// feed a pipeline with random events.
+	events := xrand.StreamPick(ctx, []string{"click", "view", "purchase"})
+	for i := 0; i < 5; i++ {
+		fmt.Println(<-events)
+	}
+}
+
+func ExamplePickIndexed() {
+	// pick a random element, and use its index into a parallel slice.
+	names := []string{"Alice", "Bob", "Carol"}
+	ages := []int{30, 25, 35}
+	idx, name := xrand.PickIndexed(names)
+	fmt.Println(name, ages[idx])
+}
+
+func ExampleOneOf() {
+	// pick a random color, inline.
+	color := xrand.OneOf("red", "green", "blue")
+	fmt.Println(color)
+}
+
+func ExamplePickSyncMapKey() {
+	var cache sync.Map
+	cache.Store("key1", "value1")
+	cache.Store("key2", "value2")
+
+	// pick a random cache key, for example for eviction.
+	if key, ok := xrand.PickSyncMapKey(&cache); ok {
+		fmt.Println(key)
+	}
+}
+
+func ExamplePickWhere() {
+	// pick a random healthy backend.
+	type backend struct {
+		addr    string
+		healthy bool
+	}
+	backends := []backend{{"10.0.0.1", true}, {"10.0.0.2", false}, {"10.0.0.3", true}}
+	if b, ok := xrand.PickWhere(backends, func(b backend) bool { return b.healthy }); ok {
+		fmt.Println(b.addr)
+	}
+}
+
+func ExamplePickExcluding() {
+	// pick a shard, avoiding the unhealthy ones.
+	shards := []string{"shard1", "shard2", "shard3"}
+	unhealthy := map[string]struct{}{"shard2": {}}
+	if shard, ok := xrand.PickExcluding(shards, unhealthy); ok {
+		fmt.Println(shard)
+	}
+}
+
+func ExamplePickValidated() {
+	// pick a log level, accepted by the config validation.
+	levels := []string{"debug", "info", "warn", "error", "fatal"}
+	allowed := func(level string) bool { return level != "fatal" }
+	level, err := xrand.PickValidated(levels, allowed)
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+	fmt.Println(level)
+}
@@ -0,0 +1,319 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand
+
+import (
+	"math"
+	"sync"
+)
+
+// NoRepeatPicker picks random elements from a list, never returning
+// the same element twice in a row (as long as the list has at least 2 distinct elements).
+// It is useful, for example, for shuffled playlists.
+// It is safe for concurrent use by multiple goroutines.
+type NoRepeatPicker[T comparable] struct {
+	mu          sync.Mutex
+	items       []T
+	hasDistinct bool // whether items contains at least 2 distinct elements.
+	last        T    // last returned element.
+	hasLast     bool // whether an element was returned before.
+}
+
+// NewNoRepeatPicker instantiates a new NoRepeatPicker, picking from given items.
+// Items are copied, further changes on the provided slice do not affect the picker.
+// It panics if items is empty.
+func NewNoRepeatPicker[T comparable](items []T) *NoRepeatPicker[T] {
+	if len(items) == 0 {
+		panic("invalid argument to NewNoRepeatPicker")
+	}
+
+	picker := &NoRepeatPicker[T]{
+		items: append([]T(nil), items...),
+	}
+	for _, item := range items[1:] {
+		if item != items[0] {
+			picker.hasDistinct = true
+
+			break
+		}
+	}
+
+	return picker
+}
+
+// Next returns a random element, different from the previously returned one.
+func (p *NoRepeatPicker[T]) Next() T {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	item := p.items[globalRand.Intn(len(p.items))]
+	for p.hasDistinct && p.hasLast && item == p.last { // resample
+		item = p.items[globalRand.Intn(len(p.items))]
+	}
+	p.last, p.hasLast = item, true
+
+	return item
+}
+
+// QuotaPicker picks random elements proportionally to their remaining quota,
+// without replacement: every pick decrements the picked element's quota,
+// so that each element is returned exactly its quota number of times.
+// It is useful, for example, to distribute tasks to workers with capacity limits.
+// It is safe for concurrent use by multiple goroutines.
+type QuotaPicker[T comparable] struct {
+	mu        sync.Mutex
+	items     []T
+	remaining []int // remaining quota of each item.
+	total     int   // total remaining quota.
+}
+
+// NewQuotaPicker instantiates a new QuotaPicker, for given elements and their quotas.
+// Elements with a non-positive quota are ignored.
+func NewQuotaPicker[T comparable](quotas map[T]int) *QuotaPicker[T] {
+	picker := &QuotaPicker[T]{
+		items:     make([]T, 0, len(quotas)),
+		remaining: make([]int, 0, len(quotas)),
+	}
+	for item, quota := range quotas {
+		if quota > 0 {
+			picker.items = append(picker.items, item)
+			picker.remaining = append(picker.remaining, quota)
+			picker.total += quota
+		}
+	}
+
+	return picker
+}
+
+// Next returns a random element, picked proportionally to the remaining quotas.
+// It returns false if all quotas are exhausted.
+func (p *QuotaPicker[T]) Next() (T, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.total == 0 {
+		var zero T
+
+		return zero, false
+	}
+
+	unit := globalRand.Intn(p.total) // pick a random unit of quota, and find out its owner.
+	for idx, quota := range p.remaining {
+		if unit < quota {
+			p.remaining[idx]--
+			p.total--
+
+			return p.items[idx], true
+		}
+		unit -= quota
+	}
+
+	panic("unreachable")
+}
+
+// Remaining returns the total remaining quota.
+func (p *QuotaPicker[T]) Remaining() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.total
+}
+
+// ShuffleCycler returns elements in a random order, each exactly once per cycle;
+// after a cycle is exhausted, elements are reshuffled and a new cycle begins.
+// This is the classic "shuffle bag" pattern, used to avoid streaks of the same element.
+// It is safe for concurrent use by multiple goroutines.
+type ShuffleCycler[T any] struct {
+	mu    sync.Mutex
+	items []T
+	next  int // index of next element to return, in the current cycle.
+}
+
+// NewShuffleCycler instantiates a new ShuffleCycler over given items.
+// Items are copied, further changes on the provided slice do not affect the cycler.
+// It panics if items is empty.
+func NewShuffleCycler[T any](items []T) *ShuffleCycler[T] {
+	if len(items) == 0 {
+		panic("invalid argument to NewShuffleCycler")
+	}
+
+	return &ShuffleCycler[T]{
+		items: append([]T(nil), items...),
+		next:  len(items), // triggers a shuffle on first call.
+	}
+}
+
+// Next returns the next element of the current cycle.
+func (c *ShuffleCycler[T]) Next() T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.next == len(c.items) { // cycle exhausted, begin a new one.
+		globalRand.Shuffle(len(c.items), func(i, j int) {
+			c.items[i], c.items[j] = c.items[j], c.items[i]
+		})
+		c.next = 0
+	}
+	item := c.items[c.next]
+	c.next++
+
+	return item
+}
+
+// WeightedBag returns elements drawn without replacement according to their weights within a cycle:
+// each element appears exactly its weight no. of times per cycle, in random order;
+// after a cycle is exhausted, the bag is refilled and a new cycle begins.
+// This smooths out the streaks a pure weighted random pick produces, combining
+// the "shuffle bag" pattern (see [ShuffleCycler]) with weights.
+// It is safe for concurrent use by multiple goroutines.
+type WeightedBag[T comparable] struct {
+	cycler *ShuffleCycler[T]
+}
+
+// NewWeightedBag instantiates a new WeightedBag, for given elements and their weights.
+// Elements with a non-positive weight are ignored.
+// It panics if there is no element with a positive weight.
+func NewWeightedBag[T comparable](weights map[T]int) *WeightedBag[T] {
+	var bag []T
+	for item, weight := range weights {
+		for i := 0; i < weight; i++ {
+			bag = append(bag, item)
+		}
+	}
+	if len(bag) == 0 {
+		panic("invalid argument to NewWeightedBag")
+	}
+
+	return &WeightedBag[T]{cycler: NewShuffleCycler(bag)}
+}
+
+// Next returns the next element of the current cycle.
+func (b *WeightedBag[T]) Next() T {
+	return b.cycler.Next()
+}
+
+// CycleLen returns the length of a cycle, which is the sum of the positive weights.
+func (b *WeightedBag[T]) CycleLen() int {
+	return len(b.cycler.items)
+}
+
+// WeightedRing is a fixed-size ring buffer, from which random elements are sampled
+// favoring the newer ones: an element's weight is decay^age, where age is 0 for the newest element,
+// 1 for the one added before it, and so on. When the ring is full, adding an element
+// overwrites the oldest one.
+// It is useful, for example, to replay recent events more often than old ones.
+// It is safe for concurrent use by multiple goroutines.
+type WeightedRing[T any] struct {
+	mu    sync.Mutex
+	items []T
+	decay float64
+	next  int // index where the next element is added.
+	size  int // no. of elements in the ring.
+}
+
+// NewWeightedRing instantiates a new WeightedRing, holding up to capacity elements.
+// The decay is the weight ratio between an element and the next newer one, in (0, 1]:
+// the lower it is, the more newer elements are favored; a decay of 1 samples uniformly.
+// It panics if capacity <= 0 or decay is not in (0, 1].
+func NewWeightedRing[T any](capacity int, decay float64) *WeightedRing[T] {
+	if capacity <= 0 || !(decay > 0 && decay <= 1) { // Note: negated condition also catches NaN.
+		panic("invalid argument to NewWeightedRing")
+	}
+
+	return &WeightedRing[T]{
+		items: make([]T, capacity),
+		decay: decay,
+	}
+}
+
+// Add adds item as the newest element, overwriting the oldest one if the ring is full.
+func (r *WeightedRing[T]) Add(item T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.items[r.next] = item
+	r.next = (r.next + 1) % len(r.items)
+	if r.size < len(r.items) {
+		r.size++
+	}
+}
+
+// Sample returns a random element, picked with a probability proportional to decay^age.
+// It returns false if the ring is empty.
+func (r *WeightedRing[T]) Sample() (T, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.size == 0 {
+		var zero T
+
+		return zero, false
+	}
+
+	var age int
+	if r.decay == 1 {
+		age = globalRand.Intn(r.size)
+	} else {
+		// inverse transform sampling of the truncated geometric distribution:
+		// P(age < k) = (1 - decay^k) / (1 - decay^size).
+		total := 1 - math.Pow(r.decay, float64(r.size))
+		age = int(math.Log1p(-Float64()*total) / math.Log(r.decay))
+		if age >= r.size { // float rounding errors.
+			age = r.size - 1
+		}
+	}
+	idx := (r.next - 1 - age + 2*len(r.items)) % len(r.items)
+
+	return r.items[idx], true
+}
+
+// InverseFrequencyPicker picks random elements, favoring the ones picked less often so far:
+// an element is picked with a probability proportional to 1/(count+1), count being the no. of times
+// it was picked before. It smooths the load across elements, like backends, without a strict round-robin.
+// It is safe for concurrent use by multiple goroutines.
+type InverseFrequencyPicker[T comparable] struct {
+	mu      sync.Mutex
+	items   []T
+	counts  []int     // no. of times each item was picked.
+	weights []float64 // 1/(count+1) of each item.
+}
+
+// NewInverseFrequencyPicker instantiates a new InverseFrequencyPicker, picking from given items.
+// Items are copied, further changes on the provided slice do not affect the picker.
+// Duplicated items are tracked separately, thus are picked more often.
+// It panics if items is empty.
+func NewInverseFrequencyPicker[T comparable](items []T) *InverseFrequencyPicker[T] {
+	if len(items) == 0 {
+		panic("invalid argument to NewInverseFrequencyPicker")
+	}
+
+	picker := &InverseFrequencyPicker[T]{
+		items:   append([]T(nil), items...),
+		counts:  make([]int, len(items)),
+		weights: make([]float64, len(items)),
+	}
+	for idx := range picker.weights {
+		picker.weights[idx] = 1
+	}
+
+	return picker
+}
+
+// Pick returns a random element, picked with a probability proportional to 1/(count+1).
+func (p *InverseFrequencyPicker[T]) Pick() T {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var total float64 // Note: computed on each pick, not to accumulate float rounding errors.
+	for _, weight := range p.weights {
+		total += weight
+	}
+	idx := pickWeightedIndex(p.weights, total)
+	p.counts[idx]++
+	p.weights[idx] = 1 / float64(p.counts[idx]+1)
+
+	return p.items[idx]
+}
@@ -0,0 +1,568 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand_test
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/actforgood/xrand"
+)
+
+func TestNoRepeatPicker(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no immediate repeats", testNoRepeatPickerNoRepeats)
+	t.Run("no immediate repeats with duplicated items", testNoRepeatPickerDuplicatedItems)
+	t.Run("single element", testNoRepeatPickerSingleElement)
+	t.Run("panics for empty items", testNoRepeatPickerPanics)
+}
+
+func testNoRepeatPickerNoRepeats(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		items   = []string{"a", "b", "c", "d"}
+		subject = xrand.NewNoRepeatPicker(items)
+		counts  = make(map[string]int, len(items))
+		last    = subject.Next()
+	)
+
+	for i := 0; i < 10000; i++ {
+		// act
+		result := subject.Next()
+
+		// assert
+		assertTrue(t, result != last)
+		counts[result]++
+		last = result
+	}
+	assertEqual(t, len(items), len(counts))
+}
+
+func testNoRepeatPickerDuplicatedItems(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject = xrand.NewNoRepeatPicker([]int{1, 1, 1, 2})
+		last    = subject.Next()
+	)
+
+	for i := 0; i < 1000; i++ {
+		// act
+		result := subject.Next()
+
+		// assert
+		assertTrue(t, result != last)
+		last = result
+	}
+}
+
+func testNoRepeatPickerSingleElement(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	subjects := []*xrand.NoRepeatPicker[string]{
+		xrand.NewNoRepeatPicker([]string{"a"}),
+		xrand.NewNoRepeatPicker([]string{"a", "a"}),
+	}
+
+	for _, subject := range subjects {
+		for i := 0; i < 10; i++ {
+			// act
+			result := subject.Next()
+
+			// assert
+			assertEqual(t, "a", result)
+		}
+	}
+}
+
+func testNoRepeatPickerPanics(t *testing.T) {
+	t.Parallel()
+
+	// act & assert
+	assertPanics(t, func() {
+		_ = xrand.NewNoRepeatPicker([]int{})
+	})
+}
+
+func TestQuotaPicker(t *testing.T) {
+	t.Parallel()
+
+	t.Run("each item is returned exactly its quota times", testQuotaPickerQuotas)
+	t.Run("ordering varies", testQuotaPickerOrderingVaries)
+	t.Run("picks proportionally to remaining quota", testQuotaPickerProportional)
+	t.Run("no quotas", testQuotaPickerEmpty)
+}
+
+func testQuotaPickerQuotas(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		quotas  = map[string]int{"worker1": 5, "worker2": 10, "worker3": 1, "worker4": 0, "worker5": -3}
+		subject = xrand.NewQuotaPicker(quotas)
+		counts  = make(map[string]int, len(quotas))
+	)
+	assertEqual(t, 16, subject.Remaining())
+
+	for {
+		// act
+		item, ok := subject.Next()
+		if !ok {
+			break
+		}
+		counts[item]++
+	}
+
+	// assert
+	assertEqual(t, 3, len(counts))
+	assertEqual(t, 5, counts["worker1"])
+	assertEqual(t, 10, counts["worker2"])
+	assertEqual(t, 1, counts["worker3"])
+	assertEqual(t, 0, subject.Remaining())
+	_, ok := subject.Next()
+	assertTrue(t, !ok)
+}
+
+func testQuotaPickerOrderingVaries(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		quotas    = map[int]int{1: 3, 2: 3, 3: 3}
+		orderings = make(map[string]struct{})
+	)
+
+	for i := 0; i < 100; i++ {
+		subject := xrand.NewQuotaPicker(quotas)
+		ordering := ""
+		for {
+			// act
+			item, ok := subject.Next()
+			if !ok {
+				break
+			}
+			ordering += fmt.Sprint(item)
+		}
+		orderings[ordering] = struct{}{}
+	}
+
+	// assert
+	assertTrue(t, len(orderings) > 1)
+}
+
+func testQuotaPickerProportional(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 20000
+	var (
+		quotas = map[string]int{"a": 1, "b": 3}
+		picks  = make([]bool, iterations) // whether b was picked first.
+	)
+
+	for i := range picks {
+		subject := xrand.NewQuotaPicker(quotas)
+
+		// act
+		item, _ := subject.Next()
+
+		picks[i] = item == "b"
+	}
+
+	// assert
+	assertTrue(t, math.Abs(trueRate(picks)-0.75) < 0.02)
+}
+
+func testQuotaPickerEmpty(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	subject := xrand.NewQuotaPicker(map[string]int{})
+
+	// act
+	item, ok := subject.Next()
+
+	// assert
+	assertTrue(t, !ok)
+	assertEqual(t, "", item)
+}
+
+func TestShuffleCycler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("every element appears once per cycle", testShuffleCyclerCycles)
+	t.Run("concurrent calls", testShuffleCyclerConcurrency)
+	t.Run("panics for empty items", testShuffleCyclerPanics)
+}
+
+func testShuffleCyclerCycles(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		items   = []int{1, 2, 3, 4, 5, 6, 7, 8}
+		subject = xrand.NewShuffleCycler(items)
+		orders  = make(map[string]struct{})
+	)
+
+	for cycle := 0; cycle < 50; cycle++ {
+		result := make([]int, len(items))
+		for i := range result {
+			// act
+			result[i] = subject.Next()
+		}
+
+		// assert
+		assertSamePermutation(t, items, result)
+		orders[fmt.Sprint(result)] = struct{}{}
+	}
+	assertTrue(t, len(orders) > 1)
+}
+
+func testShuffleCyclerConcurrency(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const (
+		goroutines = 4
+		cycles     = 25
+	)
+	var (
+		items   = []string{"a", "b", "c", "d", "e"}
+		subject = xrand.NewShuffleCycler(items)
+		results = make(chan string, goroutines*cycles*len(items))
+		wg      sync.WaitGroup
+	)
+
+	// act
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < cycles*len(items); i++ {
+				results <- subject.Next()
+			}
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	// assert
+	counts := make(map[string]int, len(items))
+	for item := range results {
+		counts[item]++
+	}
+	for _, item := range items {
+		assertEqual(t, goroutines*cycles, counts[item])
+	}
+}
+
+func testShuffleCyclerPanics(t *testing.T) {
+	t.Parallel()
+
+	// act & assert
+	assertPanics(t, func() {
+		_ = xrand.NewShuffleCycler([]int{})
+	})
+}
+
+func TestWeightedBag(t *testing.T) {
+	t.Parallel()
+
+	t.Run("per cycle counts match weights", testWeightedBagCycles)
+	t.Run("panics for no positive weight", testWeightedBagPanics)
+}
+
+func testWeightedBagCycles(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		weights   = map[string]int{"common": 5, "rare": 2, "epic": 1, "disabled": 0, "invalid": -3}
+		subject   = xrand.NewWeightedBag(weights)
+		orderings = make(map[string]struct{})
+	)
+	assertEqual(t, 8, subject.CycleLen())
+
+	for cycle := 0; cycle < 50; cycle++ {
+		var (
+			counts   = make(map[string]int, len(weights))
+			ordering = make([]string, subject.CycleLen())
+		)
+		for i := range ordering {
+			// act
+			ordering[i] = subject.Next()
+			counts[ordering[i]]++
+		}
+
+		// assert
+		assertEqual(t, 3, len(counts))
+		assertEqual(t, 5, counts["common"])
+		assertEqual(t, 2, counts["rare"])
+		assertEqual(t, 1, counts["epic"])
+		orderings[strings.Join(ordering, ",")] = struct{}{}
+	}
+	assertTrue(t, len(orderings) > 1)
+}
+
+func testWeightedBagPanics(t *testing.T) {
+	t.Parallel()
+
+	// act & assert
+	assertPanics(t, func() { _ = xrand.NewWeightedBag(map[string]int{}) })
+	assertPanics(t, func() { _ = xrand.NewWeightedBag(map[string]int{"a": 0, "b": -1}) })
+}
+
+func TestWeightedRing(t *testing.T) {
+	t.Parallel()
+
+	t.Run("newer elements are favored", testWeightedRingFavorsNewer)
+	t.Run("oldest elements are overwritten", testWeightedRingOverwrites)
+	t.Run("decay of 1 is uniform", testWeightedRingUniform)
+	t.Run("empty ring", testWeightedRingEmpty)
+	t.Run("panics for invalid arguments", testWeightedRingPanics)
+}
+
+func testWeightedRingFavorsNewer(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 50000
+	var (
+		subject = xrand.NewWeightedRing[int](5, 0.5)
+		items   = []int{1, 2, 3, 4, 5}
+		weights = []float64{0.0625, 0.125, 0.25, 0.5, 1} // 0.5^age, 5 is the newest.
+		counts  = make(map[int]int, len(items))
+	)
+	for _, item := range items {
+		subject.Add(item)
+	}
+
+	for i := 0; i < iterations; i++ {
+		// act
+		result, ok := subject.Sample()
+
+		// assert
+		assertTrue(t, ok)
+		counts[result]++
+	}
+	assertWeightedDistribution(t, items, weights, counts, iterations)
+	for i := 1; i < len(items); i++ {
+		assertTrue(t, counts[items[i]] > counts[items[i-1]])
+	}
+}
+
+func testWeightedRingOverwrites(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 30000
+	var (
+		subject = xrand.NewWeightedRing[int](3, 0.8)
+		items   = []int{3, 4, 5}
+		weights = []float64{0.64, 0.8, 1}
+		counts  = make(map[int]int, len(items))
+	)
+	for item := 1; item <= 5; item++ {
+		subject.Add(item)
+	}
+
+	for i := 0; i < iterations; i++ {
+		// act
+		result, ok := subject.Sample()
+
+		// assert
+		assertTrue(t, ok)
+		counts[result]++
+	}
+	assertEqual(t, 3, len(counts))
+	assertWeightedDistribution(t, items, weights, counts, iterations)
+}
+
+func testWeightedRingUniform(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 40000
+	var (
+		subject = xrand.NewWeightedRing[int](4, 1)
+		counts  = make([]int, 4)
+	)
+	for item := 0; item < 4; item++ {
+		subject.Add(item)
+	}
+
+	for i := 0; i < iterations; i++ {
+		// act
+		result, ok := subject.Sample()
+
+		// assert
+		assertTrue(t, ok)
+		counts[result]++
+	}
+	assertUniform(t, counts, iterations, 0.05)
+}
+
+func testWeightedRingEmpty(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	subject := xrand.NewWeightedRing[string](10, 0.9)
+
+	// act
+	item, ok := subject.Sample()
+
+	// assert
+	assertTrue(t, !ok)
+	assertEqual(t, "", item)
+}
+
+func testWeightedRingPanics(t *testing.T) {
+	t.Parallel()
+
+	// act & assert
+	assertPanics(t, func() { _ = xrand.NewWeightedRing[int](0, 0.5) })
+	assertPanics(t, func() { _ = xrand.NewWeightedRing[int](-1, 0.5) })
+	assertPanics(t, func() { _ = xrand.NewWeightedRing[int](5, 0) })
+	assertPanics(t, func() { _ = xrand.NewWeightedRing[int](5, -0.5) })
+	assertPanics(t, func() { _ = xrand.NewWeightedRing[int](5, 1.5) })
+	assertPanics(t, func() { _ = xrand.NewWeightedRing[int](5, math.NaN()) })
+}
+
+func TestInverseFrequencyPicker(t *testing.T) {
+	t.Parallel()
+
+	t.Run("counts converge toward equal", testInverseFrequencyPickerConverges)
+	t.Run("less picked elements are favored", testInverseFrequencyPickerFavorsLessPicked)
+	t.Run("panics for empty items", testInverseFrequencyPickerPanics)
+}
+
+func testInverseFrequencyPickerConverges(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const (
+		runs  = 20
+		picks = 1000
+	)
+	var (
+		items    = []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+		mean     = float64(picks) / float64(len(items))
+		variance float64
+		// variance of the counts, if elements were picked uniformly at random (binomial variance).
+		uniformVariance = picks * (1 / float64(len(items))) * (1 - 1/float64(len(items)))
+	)
+
+	for r := 0; r < runs; r++ {
+		var (
+			subject = xrand.NewInverseFrequencyPicker(items)
+			counts  = make([]int, len(items))
+		)
+		for i := 0; i < picks; i++ {
+			// act
+			counts[subject.Pick()]++
+		}
+		for _, count := range counts {
+			variance += (float64(count) - mean) * (float64(count) - mean)
+		}
+	}
+	variance /= runs * float64(len(items))
+
+	// assert
+	assertTrue(t, variance < uniformVariance/2)
+}
+
+func testInverseFrequencyPickerFavorsLessPicked(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 20000
+	counts := make(map[string]int, 2)
+
+	for i := 0; i < iterations; i++ {
+		subject := xrand.NewInverseFrequencyPicker([]string{"a", "b"})
+		first := subject.Pick()
+
+		// act
+		result := subject.Pick()
+
+		// assert: the other element has weight 1, while the first picked one has weight 1/2.
+		if result == first {
+			counts["same"]++
+		} else {
+			counts["other"]++
+		}
+	}
+	assertWeightedDistribution(t, []string{"same", "other"}, []float64{1, 2}, counts, iterations)
+}
+
+func testInverseFrequencyPickerPanics(t *testing.T) {
+	t.Parallel()
+
+	// act & assert
+	assertPanics(t, func() {
+		_ = xrand.NewInverseFrequencyPicker([]int{})
+	})
+}
+
+func ExampleNoRepeatPicker() {
+	// shuffle a playlist, never playing the same song twice in a row.
+	playlist := xrand.NewNoRepeatPicker([]string{"song1", "song2", "song3"})
+	for i := 0; i < 5; i++ {
+		fmt.Println(playlist.Next())
+	}
+}
+
+func ExampleQuotaPicker() {
+	// distribute tasks to workers, according to their capacity.
+	workers := xrand.NewQuotaPicker(map[string]int{"worker1": 2, "worker2": 1})
+	for worker, ok := workers.Next(); ok; worker, ok = workers.Next() {
+		fmt.Println(worker)
+	}
+}
+
+func ExampleShuffleCycler() {
+	// drop loot, each item exactly once per 3 drops, in random order.
+	loot := xrand.NewShuffleCycler([]string{"sword", "shield", "potion"})
+	for i := 0; i < 6; i++ {
+		fmt.Println(loot.Next())
+	}
+}
+
+func ExampleWeightedBag() {
+	// drop loot: in every 4 drops, 3 potions and 1 sword, in random order.
+	loot := xrand.NewWeightedBag(map[string]int{"potion": 3, "sword": 1})
+	for i := 0; i < 8; i++ {
+		fmt.Println(loot.Next())
+	}
+}
+
+func ExampleWeightedRing() {
+	// replay recent events, favoring the newest ones.
+	events := xrand.NewWeightedRing[string](100, 0.9)
+	events.Add("login")
+	events.Add("view")
+	events.Add("purchase")
+	if event, ok := events.Sample(); ok {
+		fmt.Println(event)
+	}
+}
+
+func ExampleInverseFrequencyPicker() {
+	// balance requests across backends, favoring the less used ones.
+	backends := xrand.NewInverseFrequencyPicker([]string{"backend1", "backend2", "backend3"})
+	for i := 0; i < 6; i++ {
+		fmt.Println(backends.Pick())
+	}
+}
@@ -8,8 +8,12 @@ package xrand
 import (
 	cRand "crypto/rand"
 	"encoding/binary"
+	"errors"
+	"io"
+	"math"
 	mRand "math/rand"
 	"sync"
+	"sync/atomic"
 	"time"
 	"unsafe"
 )
@@ -20,10 +24,52 @@ const defaultJitterFactor = 0.2
 // globalRand is a global instance of Rand.
 var globalRand *mRand.Rand
 
+// globalSource is the concurrent safe source of globalRand.
+var globalSource *lockedSource
+
+// cryptoReader is the source of cryptographically secure random bytes.
+var cryptoReader io.Reader = cRand.Reader
+
+// now is the clock used by the time-based functionalities (like [UUIDv7], [JitterDeadline],
+// the seed fallback), it defaults to the real clock, and is replaced in tests, for deterministic outputs.
+var now = time.Now
+
+// jitterFloat64 is the source of random floats in [0.0, 1.0) the jitter is computed from,
+// it is replaceable for tests, to force a known jitter.
+var jitterFloat64 = Float64
+
+// timeSleep pauses the current goroutine, it is replaceable for tests, not to actually sleep.
+var timeSleep = time.Sleep
+
+// seededFromCrypto holds 1 if the global source was seeded with a crypto/rand seed, 0 otherwise.
+var seededFromCrypto int32
+
 // init initializes math rand with a secure random seed.
 // Is called automatically by go, only once, on this package first import elsewhere.
 func init() {
-	globalRand = mRand.New(&lockedSource{src: mRand.NewSource(getRandSeed())})
+	globalSource = &lockedSource{src: mRand.NewSource(1)}
+	globalRand = mRand.New(globalSource)
+	seedGlobalSource()
+}
+
+// seedGlobalSource seeds the global source with a random seed,
+// and records whether the seed was obtained from crypto/rand.
+func seedGlobalSource() {
+	seed, fromCrypto := getRandSeed()
+	globalSource.Seed(seed)
+	if fromCrypto {
+		atomic.StoreInt32(&seededFromCrypto, 1)
+	} else {
+		atomic.StoreInt32(&seededFromCrypto, 0)
+	}
+}
+
+// SeededFromCrypto returns whether the global source (used by package level functions)
+// was seeded with a crypto/rand generated seed.
+// A false value means crypto/rand was unavailable and the seed fell back to
+// the current Unix timestamp, which is a security signal worth monitoring.
+func SeededFromCrypto() bool {
+	return atomic.LoadInt32(&seededFromCrypto) == 1
 }
 
 // lockedSource allows a random number generator to be used by multiple goroutines
@@ -51,22 +97,52 @@ func (ls *lockedSource) Seed(seed int64) {
 	ls.Unlock()
 }
 
-// getRandSeed returns a random seed number.
+// int63s fills dst with random int63 numbers, acquiring the lock only once.
+func (ls *lockedSource) int63s(dst []int64) {
+	ls.Lock()
+	for i := range dst {
+		dst[i] = ls.src.Int63()
+	}
+	ls.Unlock()
+}
+
+// getRandSeed returns a random seed number, and whether it was obtained from crypto/rand.
 // Uses crypto/rand for that.
 // Related discussions upon security:
 // 1. https://github.com/golang/go/issues/11871#issuecomment-126350652
 // 2. https://stackoverflow.com/a/35208651
 // 3. https://stackoverflow.com/a/54491783
-func getRandSeed() int64 {
+func getRandSeed() (seed int64, fromCrypto bool) {
 	var b [8]byte
-	_, err := cRand.Read(b[:])
-	if err == nil {
+	if err := readCryptoRand(b[:]); err == nil {
 		// mask off sign bit to ensure positive number
-		return int64(binary.LittleEndian.Uint64(b[:]) & (1<<63 - 1))
+		return int64(binary.LittleEndian.Uint64(b[:]) & (1<<63 - 1)), true
 	}
 
 	// fallback on the common Unix timestamp
-	return time.Now().UnixNano()
+	return now().UnixNano(), false
+}
+
+// readMathRand fills b with random bytes generated by the global math rand.
+// It never returns an error, it has the signature of readCryptoRand for interchangeability.
+func readMathRand(b []byte) error {
+	for i := 0; i < len(b); {
+		randomInt63 := globalRand.Int63()
+		for j := 0; j < 7 && i < len(b); j++ { // 7 bytes fit in an int63
+			b[i] = byte(randomInt63)
+			randomInt63 >>= 8
+			i++
+		}
+	}
+
+	return nil
+}
+
+// readCryptoRand fills b with cryptographically secure random bytes.
+func readCryptoRand(b []byte) error {
+	_, err := io.ReadFull(cryptoReader, b)
+
+	return err
 }
 
 // Intn generates a random integer in range [0,n).
@@ -81,24 +157,66 @@ func IntnBetween(min, max int) int {
 	return globalRand.Intn(max-min) + min
 }
 
+// ErrInvalidRange is returned when a range's upper bound is not greater than its lower bound.
+var ErrInvalidRange = errors.New("xrand: upper bound must be greater than lower bound")
+
+// IntnSafe generates a random integer in range [0,n), like [Intn].
+// Instead of panicking, it returns [ErrInvalidRange] if n <= 0,
+// being suitable for bounds coming from external input.
+func IntnSafe(n int) (int, error) {
+	return IntnBetweenSafe(0, n)
+}
+
+// IntnBetweenSafe generates a random integer in range [min,max), like [IntnBetween].
+// Instead of panicking, it returns [ErrInvalidRange] if max <= min,
+// being suitable for bounds coming from external input.
+// Unlike [IntnBetween], it also handles ranges wider than the max int, like [math.MinInt, math.MaxInt).
+func IntnBetweenSafe(min, max int) (int, error) {
+	if max <= min {
+		return 0, ErrInvalidRange
+	}
+
+	span := uint64(max) - uint64(min) // Note: correct even if max-min overflows int.
+	if span <= math.MaxInt64 {
+		return min + int(globalRand.Int63n(int64(span))), nil
+	}
+	// span is above half of uint64's range, thus a rejection happens less than half of the times.
+	for {
+		if r := globalRand.Uint64(); r < span {
+			return int(uint64(min) + r), nil
+		}
+	}
+}
+
 // Float64 generates a random float64 in range [0.0, 1.0).
 func Float64() float64 {
 	return globalRand.Float64()
 }
 
+// Float64Open generates a random float64 in the open range (0.0, 1.0).
+// Unlike [Float64], it never returns 0, the rare exact zero draws being resampled,
+// making it safe for transforms like log(u), which would otherwise produce -Inf.
+func Float64Open() float64 {
+	for {
+		if f := globalRand.Float64(); f > 0 {
+			return f
+		}
+	}
+}
+
 // Jitter returns a time.Duration altered with a random factor.
 // This allows clients to avoid converging on periodic behaviour.
 // If maxFactor is <= 0.0, a suggested default value will be chosen.
 func Jitter(duration time.Duration, maxFactor ...float64) time.Duration {
-	// Note: credits to https://github.com/kubernetes/apimachinery/blob/v0.24.2/pkg/util/wait/wait.go#L196
-	factor := defaultJitterFactor
-	if len(maxFactor) > 0 && maxFactor[0] > 0.0 {
-		factor = maxFactor[0]
-	}
+	return jitterDuration(duration, jitterFactor(maxFactor))
+}
 
+// jitterDuration returns duration altered with a random factor in [-factor, factor).
+func jitterDuration(duration time.Duration, factor float64) time.Duration {
+	// Note: credits to https://github.com/kubernetes/apimachinery/blob/v0.24.2/pkg/util/wait/wait.go#L196
 	newDuration := time.Duration(0)
 	for newDuration <= 0 {
-		randRange := 2*Float64() - 1 // [-1.0, 1.0)
+		randRange := 2*jitterFloat64() - 1 // [-1.0, 1.0)
 		jitter := time.Duration(randRange * factor * float64(duration))
 		newDuration = duration + jitter
 	}
@@ -111,25 +229,129 @@ const (
 	AlphanumAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
 	// DigitsAlphabet consists of 1..9 numbers.
 	DigitsAlphabet = "0123456789"
+	// Base32CrockfordAlphabet consists of Crockford's base32 symbols: digits and Ascii uppercase
+	// letters, except I, L, O and U. It is suitable for codes humans read aloud or type.
+	// See https://www.crockford.com/base32.html.
+	Base32CrockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
 )
 
 // String generates a random string of length n with letters from the alphabet.
 // Alphabet is optional and defaults to [AlphanumAlphabet] if not provided.
+// Alphabets whose length is a power of two (2, 4, 8, 16, 32, 64, ...) are the fastest,
+// as random bits are mapped directly to letters, without any rejection.
 func String(n int, alphabet ...string) string {
-	// Note: implementation details are explained here: https://stackoverflow.com/a/31832326
-	// See also similar impl: https://github.com/kubernetes/apimachinery/blob/v0.27.3/pkg/util/rand/rand.go#L98
-	var a string
+	b := make([]byte, n)
+	fillString(b, alphabetOrDefault(alphabet))
+
+	return *(*string)(unsafe.Pointer(&b))
+}
+
+// StringSafe generates a random string of length n with letters from the alphabet, like [String],
+// but the result is built with a plain string(bytes) conversion, instead of an unsafe pointer conversion.
+// Alphabet is optional and defaults to [AlphanumAlphabet] if not provided.
+// It suits environments wary of unsafe (like running with -d=checkptr), at the cost of
+// an extra allocation and copy, for strings too long for the intermediate buffer to live on stack.
+// See also [StringPooled], which avoids unsafe without the extra allocation.
+func StringSafe(n int, alphabet ...string) string {
+	b := make([]byte, n)
+	fillString(b, alphabetOrDefault(alphabet))
+
+	return string(b)
+}
+
+// maxPooledStringBufferSize is the max capacity of a buffer [StringPooled] puts back in the pool,
+// bigger buffers are left to the garbage collector, not to pin rarely needed memory.
+const maxPooledStringBufferSize = 4096
+
+// stringBufferPool is the pool of byte buffers used by [StringPooled].
+var stringBufferPool = sync.Pool{
+	New: func() any {
+		b := make([]byte, 0, 64)
+
+		return &b
+	},
+}
+
+// StringPooled generates a random string of length n with letters from the alphabet, like [String].
+// Alphabet is optional and defaults to [AlphanumAlphabet] if not provided.
+// The intermediate buffer is taken from a sync.Pool and reused across calls,
+// the returned string being a safe copy of it, that does not alias any pooled memory.
+// This way the only allocation is the returned string itself, and no unsafe conversion is made.
+// It is meant for hot paths generating strings at very high rates.
+func StringPooled(n int, alphabet ...string) string {
+	bufPtr := stringBufferPool.Get().(*[]byte)
+	if cap(*bufPtr) < n {
+		*bufPtr = make([]byte, n)
+	}
+	buf := (*bufPtr)[:n]
+	fillString(buf, alphabetOrDefault(alphabet))
+	str := string(buf) // copy, buf is going to be reused.
+
+	if cap(buf) <= maxPooledStringBufferSize {
+		*bufPtr = buf[:0]
+		stringBufferPool.Put(bufPtr)
+	}
+
+	return str
+}
+
+// alphabetOrDefault returns the optional alphabet, or [AlphanumAlphabet] if not provided / empty.
+func alphabetOrDefault(alphabet []string) string {
 	if len(alphabet) > 0 && len(alphabet[0]) > 0 {
-		a = alphabet[0]
+		return alphabet[0]
+	}
+
+	return AlphanumAlphabet
+}
+
+// fillString fills b with random letters from the alphabet a.
+func fillString(b []byte, a string) {
+	if len(a)&(len(a)-1) == 0 {
+		fillStringPowerOfTwo(b, a)
 	} else {
-		a = AlphanumAlphabet
+		fillStringRejection(b, a)
+	}
+}
+
+// fillStringPowerOfTwo fills b with random letters from the alphabet a, whose length is a power of two.
+// Every combination of alphabetIdxBits bits is a valid index in the alphabet,
+// so bits are mapped directly to letters, no draw is ever rejected.
+func fillStringPowerOfTwo(b []byte, a string) {
+	alphabetIdxBits := countBits(len(a))
+	if alphabetIdxBits == 0 { // single letter alphabet
+		for i := range b {
+			b[i] = a[0]
+		}
+
+		return
+	}
+
+	var (
+		alphabetIdxMask int64 = 1<<alphabetIdxBits - 1
+		alphabetIdxMax        = 63 / alphabetIdxBits
+		randomInt63     int64
+		remaining       int
+	)
+	for i := range b {
+		if remaining == 0 {
+			randomInt63, remaining = globalRand.Int63(), alphabetIdxMax
+		}
+		b[i] = a[randomInt63&alphabetIdxMask]
+		randomInt63 >>= alphabetIdxBits
+		remaining--
 	}
+}
 
+// fillStringRejection fills b with random letters from the alphabet a,
+// rejecting the random indexes that fall outside the alphabet.
+func fillStringRejection(b []byte, a string) {
+	// Note: implementation details are explained here: https://stackoverflow.com/a/31832326
+	// See also similar impl: https://github.com/kubernetes/apimachinery/blob/v0.27.3/pkg/util/rand/rand.go#L98
 	var (
 		alphabetIdxBits       = countBits(len(a))      // represents the max no. of bits to represent an index in alphabet.
 		alphabetIdxMask int64 = 1<<alphabetIdxBits - 1 // 1...1b bits, of length alphabetIdxBits
 		alphabetIdxMax        = 63 / alphabetIdxBits   // no. of random letters/their indexes we can extract from an int63
-		b                     = make([]byte, n)
+		n                     = len(b)
 	)
 
 	randomInt63 := globalRand.Int63()
@@ -145,8 +367,67 @@ func String(n int, alphabet ...string) string {
 		randomInt63 >>= alphabetIdxBits
 		remaining--
 	}
+}
 
-	return *(*string)(unsafe.Pointer(&b))
+// stringsBatchSize is the no. of random int63 numbers [Strings] pulls from the source at once.
+const stringsBatchSize = 64
+
+// fillStringBatched fills b with random letters from the alphabet a, like [fillString],
+// but pulls the random int63 numbers from the source in chunks, acquiring the lock once per chunk.
+func fillStringBatched(b []byte, a string) {
+	alphabetIdxBits := countBits(len(a))
+	if alphabetIdxBits == 0 { // single letter alphabet
+		for i := range b {
+			b[i] = a[0]
+		}
+
+		return
+	}
+
+	var (
+		alphabetIdxMask int64 = 1<<alphabetIdxBits - 1 // 1...1b bits, of length alphabetIdxBits
+		alphabetIdxMax        = 63 / alphabetIdxBits   // no. of random letters/their indexes we can extract from an int63
+		batch           [stringsBatchSize]int64
+		batchIdx        = stringsBatchSize
+		randomInt63     int64
+		remaining       int
+	)
+	for i := 0; i < len(b); {
+		if remaining == 0 { // take the next random 63 bits integer, reset remaining
+			if batchIdx == stringsBatchSize { // batch consumed, pull a new one
+				globalSource.int63s(batch[:])
+				batchIdx = 0
+			}
+			randomInt63, remaining = batch[batchIdx], alphabetIdxMax
+			batchIdx++
+		}
+		if alphabetIdx := int(randomInt63 & alphabetIdxMask); alphabetIdx < len(a) {
+			b[i] = a[alphabetIdx]
+			i++
+		}
+		randomInt63 >>= alphabetIdxBits
+		remaining--
+	}
+}
+
+// Strings generates count random strings of given length with letters from the alphabet.
+// Alphabet is optional and defaults to [AlphanumAlphabet] if not provided.
+// It is the batch version of [String]: it pulls chunks of entropy from the source
+// with a single lock acquisition, and allocates all the strings at once,
+// being faster than calling [String] in a loop.
+func Strings(count, length int, alphabet ...string) []string {
+	b := make([]byte, count*length)
+	fillStringBatched(b, alphabetOrDefault(alphabet))
+
+	var (
+		allStrings = *(*string)(unsafe.Pointer(&b))
+		strs       = make([]string, count)
+	)
+	for i := range strs {
+		strs[i] = allStrings[i*length : (i+1)*length]
+	}
+
+	return strs
 }
 
 // countBits returns the no. of bits provided integer fits in.
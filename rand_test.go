@@ -6,8 +6,14 @@
 package xrand_test
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
 	"regexp"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -66,6 +72,117 @@ func TestIntnBetween(t *testing.T) {
 	}
 }
 
+func TestIntnSafe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("in range", func(t *testing.T) {
+		t.Parallel()
+
+		for _, n := range [...]int{1, 11, 256, 15678, math.MaxInt} {
+			for i := 0; i < 1000; i++ {
+				// act
+				result, err := xrand.IntnSafe(n)
+
+				// assert
+				assertNil(t, err)
+				assertTrue(t, result >= 0)
+				assertTrue(t, result < n)
+			}
+		}
+	})
+
+	t.Run("non-positive n", func(t *testing.T) {
+		t.Parallel()
+
+		for _, n := range [...]int{0, -1, math.MinInt} {
+			// act
+			result, err := xrand.IntnSafe(n)
+
+			// assert
+			assertTrue(t, errors.Is(err, xrand.ErrInvalidRange))
+			assertEqual(t, 0, result)
+		}
+	})
+}
+
+func TestIntnBetweenSafe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("in range", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		tests := [...]struct {
+			min int
+			max int
+		}{
+			{min: 0, max: 1},
+			{min: 5, max: 23},
+			{min: -100, max: -99},
+			{min: -50, max: 50},
+			{min: math.MinInt, max: math.MinInt + 10},
+			{min: math.MaxInt - 10, max: math.MaxInt},
+			{min: -1, max: math.MaxInt}, // span is above max int
+			{min: math.MinInt, max: math.MaxInt},
+		}
+
+		for _, test := range tests {
+			for i := 0; i < 1000; i++ {
+				// act
+				result, err := xrand.IntnBetweenSafe(test.min, test.max)
+
+				// assert
+				assertNil(t, err)
+				assertTrue(t, result >= test.min)
+				assertTrue(t, result < test.max)
+			}
+		}
+	})
+
+	t.Run("wide range covers both signs", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		negatives := 0
+
+		for i := 0; i < 1000; i++ {
+			// act
+			result, err := xrand.IntnBetweenSafe(math.MinInt, math.MaxInt)
+
+			// assert
+			assertNil(t, err)
+			if result < 0 {
+				negatives++
+			}
+		}
+		assertTrue(t, negatives > 400 && negatives < 600)
+	})
+
+	t.Run("inverted or empty range", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		tests := [...]struct {
+			min int
+			max int
+		}{
+			{min: 0, max: 0},
+			{min: 10, max: 5},
+			{min: -1, max: -2},
+			{min: math.MaxInt, max: math.MinInt},
+		}
+
+		for _, test := range tests {
+			// act
+			result, err := xrand.IntnBetweenSafe(test.min, test.max)
+
+			// assert
+			assertTrue(t, errors.Is(err, xrand.ErrInvalidRange))
+			assertEqual(t, 0, result)
+		}
+	})
+}
+
 func TestFloat64(t *testing.T) {
 	t.Parallel()
 
@@ -85,6 +202,73 @@ func TestFloat64(t *testing.T) {
 	}
 }
 
+func TestFloat64Open(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 100000; i++ {
+		// act
+		result := xrand.Float64Open()
+
+		// assert
+		assertTrue(t, result > 0)
+		assertTrue(t, result < 1)
+		assertTrue(t, !math.IsInf(math.Log(result), -1))
+	}
+}
+
+func TestSeededFromCrypto(t *testing.T) { // Note: not parallel, as it reseeds the global source.
+	// arrange
+	subject := xrand.SeededFromCrypto
+
+	// act & assert
+	assertTrue(t, subject())
+
+	// act: crypto/rand fails, fallback is used.
+	restore := xrand.SetCryptoReader(errReader{})
+	xrand.ReseedGlobalSource()
+	restore()
+
+	// assert
+	assertTrue(t, !subject())
+
+	// act: crypto/rand works again.
+	xrand.ReseedGlobalSource()
+
+	// assert
+	assertTrue(t, subject())
+}
+
+func TestSeedFallbackUsesClock(t *testing.T) { // Note: not parallel, as it reseeds the global source.
+	// arrange
+	fixedNow := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+	restoreCrypto := xrand.SetCryptoReader(errReader{})
+	restoreNow := xrand.SetNow(func() time.Time { return fixedNow })
+	defer xrand.ReseedGlobalSource()
+	defer restoreCrypto()
+	defer restoreNow()
+	values := func() []int {
+		xrand.ReseedGlobalSource()
+		v := make([]int, 10)
+		for i := range v {
+			v[i] = xrand.Intn(1000)
+		}
+
+		return v
+	}
+
+	// act
+	values1 := values()
+	values2 := values()
+
+	// assert
+	assertTrue(t, !xrand.SeededFromCrypto())
+	assertTrue(t, reflect.DeepEqual(values1, values2))
+	expected := rand.New(rand.NewSource(fixedNow.UnixNano()))
+	for _, v := range values1 {
+		assertEqual(t, expected.Intn(1000), v)
+	}
+}
+
 func TestJitter(t *testing.T) {
 	t.Parallel()
 
@@ -234,14 +418,74 @@ func testJitterWithCustomMaxFactor(t *testing.T) {
 	}
 }
 
+func TestJitterWithFixedFloat64(t *testing.T) { // Note: not parallel, as it replaces the global jitter source.
+	// arrange
+	tests := [...]struct {
+		name             string
+		inputFloat64     float64
+		inputDuration    time.Duration
+		inputMaxFactor   []float64
+		expectedDuration time.Duration
+	}{
+		{
+			name:             "lowest draw, default factor",
+			inputFloat64:     0,
+			inputDuration:    time.Second,
+			expectedDuration: 800 * time.Millisecond, // 1s - 1 * 0.2 * 1s
+		},
+		{
+			name:             "middle draw, no jitter",
+			inputFloat64:     0.5,
+			inputDuration:    time.Second,
+			inputMaxFactor:   []float64{0.5},
+			expectedDuration: time.Second,
+		},
+		{
+			name:             "upper quarter draw, custom factor",
+			inputFloat64:     0.75,
+			inputDuration:    time.Minute,
+			inputMaxFactor:   []float64{0.5},
+			expectedDuration: 75 * time.Second, // 1m + 0.5 * 0.5 * 1m
+		},
+		{
+			name:             "lower quarter draw, default factor",
+			inputFloat64:     0.25,
+			inputDuration:    10 * time.Second,
+			expectedDuration: 9 * time.Second, // 10s - 0.5 * 0.2 * 10s
+		},
+	}
+
+	for _, test := range tests {
+		restore := xrand.SetJitterFloat64(func() float64 { return test.inputFloat64 })
+
+		// act
+		result := xrand.Jitter(test.inputDuration, test.inputMaxFactor...)
+
+		// assert
+		restore()
+		assertEqual(t, test.expectedDuration, result)
+	}
+}
+
 func TestString(t *testing.T) {
 	t.Parallel()
 
+	testString(t, xrand.String)
+}
+
+func TestStringSafe(t *testing.T) {
+	t.Parallel()
+
+	testString(t, xrand.StringSafe)
+}
+
+func testString(t *testing.T, subject func(int, ...string) string) {
+	t.Helper()
+
 	// arrange
 	var (
-		result  string
-		subject = xrand.String
-		tests   = [...]struct {
+		result string
+		tests  = [...]struct {
 			name          string
 			inputLength   int
 			inputAlphabet string
@@ -289,6 +533,24 @@ func TestString(t *testing.T) {
 				inputAlphabet: "",
 				expectedReg:   regexp.MustCompile(`^[a-z0-9]{2}$`),
 			},
+			{
+				name:          "len = 100, power of two alphabet",
+				inputLength:   100,
+				inputAlphabet: base64Alphabet,
+				expectedReg:   regexp.MustCompile(`^[A-Za-z0-9\-_]{100}$`),
+			},
+			{
+				name:          "len = 70, alphabet = 01",
+				inputLength:   70,
+				inputAlphabet: "01",
+				expectedReg:   regexp.MustCompile(`^[01]{70}$`),
+			},
+			{
+				name:          "len = 5, single letter alphabet",
+				inputLength:   5,
+				inputAlphabet: "x",
+				expectedReg:   regexp.MustCompile(`^x{5}$`),
+			},
 		}
 	)
 
@@ -306,6 +568,214 @@ func TestString(t *testing.T) {
 	}
 }
 
+// base64Alphabet is an alphabet of 64 letters, a power of two.
+const base64Alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+
+func TestStringPowerOfTwoAlphabetIsUniform(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 10000
+	var (
+		counts = make([]int, len(base64Alphabet))
+		total  = 0
+	)
+
+	for i := 0; i < iterations; i++ {
+		// act
+		result := xrand.String(64, base64Alphabet)
+
+		// assert
+		for j := 0; j < len(result); j++ {
+			idx := strings.IndexByte(base64Alphabet, result[j])
+			if assertTrue(t, idx >= 0) {
+				counts[idx]++
+				total++
+			}
+		}
+	}
+	assertUniform(t, counts, total, 0.05)
+}
+
+func TestStringPooled(t *testing.T) {
+	t.Parallel()
+
+	t.Run("matches alphabet and length", testStringPooledOutput)
+	t.Run("concurrent use does not corrupt outputs", testStringPooledConcurrent)
+}
+
+func testStringPooledOutput(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	tests := [...]struct {
+		inputLength   int
+		inputAlphabet []string
+		expectedReg   *regexp.Regexp
+	}{
+		{inputLength: 0, expectedReg: regexp.MustCompile(`^$`)},
+		{inputLength: 16, expectedReg: regexp.MustCompile(`^[a-z0-9]{16}$`)},
+		{inputLength: 43, inputAlphabet: []string{xrand.DigitsAlphabet}, expectedReg: regexp.MustCompile(`^[0-9]{43}$`)},
+		{inputLength: 200, inputAlphabet: []string{"abc-"}, expectedReg: regexp.MustCompile(`^[a-c\-]{200}$`)},
+		{inputLength: 5000, expectedReg: regexp.MustCompile(`^[a-z0-9]+$`)}, // not pooled
+	}
+
+	for _, test := range tests {
+		for i := 0; i < 100; i++ {
+			// act
+			result := xrand.StringPooled(test.inputLength, test.inputAlphabet...)
+
+			// assert
+			assertEqual(t, test.inputLength, len(result))
+			assertTrue(t, test.expectedReg.MatchString(result))
+		}
+	}
+}
+
+func testStringPooledConcurrent(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const (
+		goroutinesNo = 16
+		stringsNo    = 500
+	)
+	var (
+		results = make([][]string, goroutinesNo)
+		copies  = make([][]string, goroutinesNo)
+		wg      sync.WaitGroup
+	)
+
+	// act
+	for g := 0; g < goroutinesNo; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < stringsNo; i++ {
+				str := xrand.StringPooled(8 + i%32)
+				results[g] = append(results[g], str)
+				copies[g] = append(copies[g], string([]byte(str))) // independent copy
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	// assert
+	unique := make(map[string]struct{}, goroutinesNo*stringsNo)
+	for g := range results {
+		for i, str := range results[g] {
+			// a string aliasing a reused buffer would have been overwritten by now.
+			assertEqual(t, copies[g][i], str)
+			unique[str] = struct{}{}
+		}
+	}
+	assertEqual(t, goroutinesNo*stringsNo, len(unique))
+}
+
+func TestStrings(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject = xrand.Strings
+		tests   = [...]struct {
+			name          string
+			inputCount    int
+			inputLength   int
+			inputAlphabet []string
+			expectedReg   *regexp.Regexp
+		}{
+			{
+				name:          "count = 100, len = 10, alphabet = xrand.AlphanumAlphabet",
+				inputCount:    100,
+				inputLength:   10,
+				inputAlphabet: []string{xrand.AlphanumAlphabet},
+				expectedReg:   regexp.MustCompile(`^[a-z0-9]{10}$`),
+			},
+			{
+				name:          "count = 1000, len = 3, alphabet = xrand.DigitsAlphabet",
+				inputCount:    1000,
+				inputLength:   3,
+				inputAlphabet: []string{xrand.DigitsAlphabet},
+				expectedReg:   regexp.MustCompile(`^[0-9]{3}$`),
+			},
+			{
+				name:          "count = 7, len = 109, alphabet = abc-",
+				inputCount:    7,
+				inputLength:   109,
+				inputAlphabet: []string{"abc-"},
+				expectedReg:   regexp.MustCompile(`^[a-c\-]{109}$`),
+			},
+			{
+				name:          "count = 2, len = 3, single letter alphabet",
+				inputCount:    2,
+				inputLength:   3,
+				inputAlphabet: []string{"a"},
+				expectedReg:   regexp.MustCompile(`^aaa$`),
+			},
+			{
+				name:          "count = 5, len = 0",
+				inputCount:    5,
+				inputLength:   0,
+				inputAlphabet: []string{"abc"},
+				expectedReg:   regexp.MustCompile(`^$`),
+			},
+			{
+				name:          "no alphabet - default alphabet",
+				inputCount:    50,
+				inputLength:   2,
+				inputAlphabet: nil,
+				expectedReg:   regexp.MustCompile(`^[a-z0-9]{2}$`),
+			},
+		}
+	)
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			// act
+			result := subject(test.inputCount, test.inputLength, test.inputAlphabet...)
+
+			// assert
+			assertEqual(t, test.inputCount, len(result))
+			for _, str := range result {
+				assertTrue(t, test.expectedReg.MatchString(str))
+			}
+		})
+	}
+
+	t.Run("count = 0", func(t *testing.T) {
+		// act
+		result := subject(0, 10)
+
+		// assert
+		assertEqual(t, 0, len(result))
+	})
+}
+
+func BenchmarkStrings(b *testing.B) {
+	b.Run("batch", func(b *testing.B) {
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			_ = xrand.Strings(1000, 16)
+		}
+	})
+
+	b.Run("naive loop", func(b *testing.B) {
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			strs := make([]string, 1000)
+			for j := range strs {
+				strs[j] = xrand.String(16)
+			}
+		}
+	})
+}
+
 func BenchmarkString(b *testing.B) {
 	b.ReportAllocs()
 	b.ResetTimer()
@@ -315,6 +785,70 @@ func BenchmarkString(b *testing.B) {
 	}
 }
 
+func BenchmarkStringPowerOfTwoAlphabet(b *testing.B) {
+	b.Run("direct bits mapping", func(b *testing.B) {
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			_ = xrand.String(32, base64Alphabet)
+		}
+	})
+
+	b.Run("rejection sampling", func(b *testing.B) {
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			_ = xrand.StringRejection(32, base64Alphabet)
+		}
+	})
+}
+
+// Note: a length above the max size the compiler allocates on stack is used,
+// to highlight the saved allocation.
+func BenchmarkStringPooled(b *testing.B) {
+	b.Run("pooled", func(b *testing.B) {
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			_ = xrand.StringPooled(256)
+		}
+	})
+
+	b.Run("not pooled safe copy", func(b *testing.B) {
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			_ = xrand.StringSafe(256)
+		}
+	})
+}
+
+// Note: a length above the max size the compiler allocates on stack is used,
+// to highlight the extra allocation of the safe conversion.
+func BenchmarkStringSafe(b *testing.B) {
+	b.Run("unsafe conversion", func(b *testing.B) {
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			_ = xrand.String(256)
+		}
+	})
+
+	b.Run("safe conversion", func(b *testing.B) {
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			_ = xrand.StringSafe(256)
+		}
+	})
+}
+
 func BenchmarkJitter(b *testing.B) {
 	b.ReportAllocs()
 	b.ResetTimer()
@@ -337,6 +871,65 @@ func assertTrue(t *testing.T, actual bool) bool {
 	return true
 }
 
+// assertEqual checks if 2 values are equal.
+// Returns successful assertion status.
+func assertEqual[T comparable](t *testing.T, expected, actual T) bool {
+	t.Helper()
+	if expected != actual {
+		t.Errorf("expected %+v, but got %+v", expected, actual)
+
+		return false
+	}
+
+	return true
+}
+
+// assertNil checks if value passed is nil.
+// Returns successful assertion status.
+func assertNil(t *testing.T, actual any) bool {
+	t.Helper()
+	if actual != nil {
+		t.Errorf("expected nil, but got %+v", actual)
+
+		return false
+	}
+
+	return true
+}
+
+// assertPanics checks if provided function panics.
+// Returns successful assertion status.
+func assertPanics(t *testing.T, fn func()) (panicked bool) {
+	t.Helper()
+	defer func() {
+		if r := recover(); r != nil {
+			panicked = true
+		} else {
+			t.Error("should have panicked")
+		}
+	}()
+	fn()
+
+	return
+}
+
+// assertUniform checks if the counts of occurrences of each value ("bucket")
+// deviate from the expected uniform count with at most tolerance (relative) factor.
+// Returns successful assertion status.
+func assertUniform(t *testing.T, counts []int, total int, tolerance float64) bool {
+	t.Helper()
+	expected := float64(total) / float64(len(counts))
+	for bucket, count := range counts {
+		if deviation := (float64(count) - expected) / expected; deviation > tolerance || deviation < -tolerance {
+			t.Errorf("bucket %d: expected ~%.0f occurrences, but got %d", bucket, expected, count)
+
+			return false
+		}
+	}
+
+	return true
+}
+
 func ExampleIntn() {
 	// generate a random int in [0, 1000)
 	randInt := xrand.Intn(1000)
@@ -349,12 +942,52 @@ func ExampleIntnBetween() {
 	fmt.Println(randInt)
 }
 
+func ExampleIntnSafe() {
+	// generate a random page number, for an externally provided pages count.
+	pagesCount := 0
+	page, err := xrand.IntnSafe(pagesCount)
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+	fmt.Println(page + 1)
+
+	// Output:
+	// xrand: upper bound must be greater than lower bound
+}
+
+func ExampleIntnBetweenSafe() {
+	// generate a random int in an externally provided range.
+	min, max := 100, 200
+	randInt, err := xrand.IntnBetweenSafe(min, max)
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+	fmt.Println(randInt)
+}
+
 func ExampleFloat64() {
 	// generate a random float in [0.0, 1.0)
 	randFloat := xrand.Float64()
 	fmt.Println(randFloat)
 }
 
+func ExampleFloat64Open() {
+	// generate an exponentially distributed delay, with a mean of 1s.
+	delay := time.Duration(-math.Log(xrand.Float64Open()) * float64(time.Second))
+	fmt.Println(delay)
+}
+
+func ExampleSeededFromCrypto() {
+	// monitor whether crypto/rand was unavailable at seeding.
+	if !xrand.SeededFromCrypto() {
+		fmt.Println("warning: xrand fell back on a time based seed")
+	}
+}
+
 func ExampleJitter() {
 	// slightly alter +/- a time.Duration
 	cacheTTL := 10 * time.Minute
@@ -368,3 +1001,21 @@ func ExampleString() {
 	randString := xrand.String(16, xrand.AlphanumAlphabet)
 	fmt.Println(randString)
 }
+
+func ExampleStringSafe() {
+	// generate a random string, without unsafe conversions.
+	randString := xrand.StringSafe(32)
+	fmt.Println(randString)
+}
+
+func ExampleStringPooled() {
+	// generate a request id on a hot path.
+	requestID := xrand.StringPooled(16)
+	fmt.Println(requestID)
+}
+
+func ExampleStrings() {
+	// generate 3 random strings of length 8, containing [0-9] letters.
+	randStrings := xrand.Strings(3, 8, xrand.DigitsAlphabet)
+	fmt.Println(randStrings)
+}
@@ -0,0 +1,97 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand
+
+import (
+	"container/heap"
+	"math"
+	"sync"
+)
+
+// WeightedReservoir samples, in a single streaming pass, k items without replacement,
+// each item being more likely to be retained the higher its weight is.
+// It implements the A-Res algorithm (Efraimidis & Spirakis, 2006): each offered item gets
+// a key u^(1/weight), u being uniform in (0, 1), and the k items with the largest keys are retained.
+// For k = 1, an item is retained with a probability of exactly weight / sum(weights).
+// It is safe for concurrent use by multiple goroutines.
+type WeightedReservoir[T any] struct {
+	mu    sync.Mutex
+	k     int
+	items reservoirHeap[T]
+}
+
+// NewWeightedReservoir instantiates a new WeightedReservoir, retaining up to k items.
+// It panics if k <= 0.
+func NewWeightedReservoir[T any](k int) *WeightedReservoir[T] {
+	if k <= 0 {
+		panic("invalid argument to NewWeightedReservoir")
+	}
+
+	return &WeightedReservoir[T]{
+		k:     k,
+		items: make(reservoirHeap[T], 0, k),
+	}
+}
+
+// Offer offers an item, with given weight, to the reservoir.
+// Items with a non-positive (or NaN) weight are ignored.
+func (r *WeightedReservoir[T]) Offer(item T, weight float64) {
+	if !(weight > 0) { // Note: negated condition also catches NaN.
+		return
+	}
+
+	// Note: log(u)/weight is used as key, which preserves the order of u^(1/weight),
+	// while not underflowing for small weights.
+	key := math.Log(Float64Open()) / weight
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.items) < r.k {
+		heap.Push(&r.items, reservoirItem[T]{item: item, key: key})
+	} else if key > r.items[0].key { // replace the item with the smallest key.
+		r.items[0] = reservoirItem[T]{item: item, key: key}
+		heap.Fix(&r.items, 0)
+	}
+}
+
+// Sample returns the retained items, at most k, in no particular order.
+func (r *WeightedReservoir[T]) Sample() []T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sample := make([]T, len(r.items))
+	for idx, item := range r.items {
+		sample[idx] = item.item
+	}
+
+	return sample
+}
+
+// reservoirItem is an item retained by [WeightedReservoir], together with its key.
+type reservoirItem[T any] struct {
+	item T
+	key  float64
+}
+
+// reservoirHeap is a min-heap of reservoir items, by their keys, implementing [heap.Interface].
+type reservoirHeap[T any] []reservoirItem[T]
+
+func (h reservoirHeap[T]) Len() int           { return len(h) }
+func (h reservoirHeap[T]) Less(i, j int) bool { return h[i].key < h[j].key }
+func (h reservoirHeap[T]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *reservoirHeap[T]) Push(x any) {
+	*h = append(*h, x.(reservoirItem[T]))
+}
+
+func (h *reservoirHeap[T]) Pop() any {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+
+	return item
+}
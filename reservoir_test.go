@@ -0,0 +1,142 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/actforgood/xrand"
+)
+
+func TestWeightedReservoir(t *testing.T) {
+	t.Parallel()
+
+	t.Run("single item is picked by weight", testWeightedReservoirSingleItem)
+	t.Run("higher weights are retained more often", testWeightedReservoirHigherWeights)
+	t.Run("size never exceeds k", testWeightedReservoirSize)
+	t.Run("invalid weights are ignored", testWeightedReservoirInvalidWeights)
+	t.Run("panics for non-positive k", testWeightedReservoirPanics)
+}
+
+func testWeightedReservoirSingleItem(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 50000
+	var (
+		items   = []string{"a", "b", "c", "d"}
+		weights = []float64{1, 2, 3, 4}
+		counts  = make(map[string]int, len(items))
+	)
+
+	for i := 0; i < iterations; i++ {
+		subject := xrand.NewWeightedReservoir[string](1)
+		for idx, item := range items {
+			// act
+			subject.Offer(item, weights[idx])
+		}
+		result := subject.Sample()
+
+		// assert
+		if assertEqual(t, 1, len(result)) {
+			counts[result[0]]++
+		}
+	}
+	assertWeightedDistribution(t, items, weights, counts, iterations)
+}
+
+func testWeightedReservoirHigherWeights(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 10000
+	counts := make([]int, 10)
+
+	for i := 0; i < iterations; i++ {
+		subject := xrand.NewWeightedReservoir[int](3)
+		for item := 0; item < 10; item++ {
+			// act
+			subject.Offer(item, float64(item+1)) // item 9 has the highest weight
+		}
+
+		// assert
+		for _, item := range subject.Sample() {
+			counts[item]++
+		}
+	}
+	for item := 1; item < len(counts); item++ {
+		assertTrue(t, counts[item] > counts[item-1])
+	}
+}
+
+func testWeightedReservoirSize(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	subject := xrand.NewWeightedReservoir[int](5)
+	assertEqual(t, 0, len(subject.Sample()))
+
+	for item := 0; item < 100; item++ {
+		// act
+		subject.Offer(item, 1+xrand.Float64())
+
+		// assert
+		result := subject.Sample()
+		expectedLen := item + 1
+		if expectedLen > 5 {
+			expectedLen = 5
+		}
+		assertEqual(t, expectedLen, len(result))
+		seen := make(map[int]struct{}, len(result))
+		for _, item := range result {
+			seen[item] = struct{}{}
+		}
+		assertEqual(t, len(result), len(seen)) // without replacement
+	}
+}
+
+func testWeightedReservoirInvalidWeights(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	subject := xrand.NewWeightedReservoir[string](3)
+
+	// act
+	subject.Offer("zero", 0)
+	subject.Offer("negative", -1)
+	subject.Offer("NaN", math.NaN())
+	subject.Offer("tiny", 1e-300)
+
+	// assert
+	result := subject.Sample()
+	if assertEqual(t, 1, len(result)) {
+		assertEqual(t, "tiny", result[0])
+	}
+}
+
+func testWeightedReservoirPanics(t *testing.T) {
+	t.Parallel()
+
+	// act & assert
+	assertPanics(t, func() { _ = xrand.NewWeightedReservoir[int](0) })
+	assertPanics(t, func() { _ = xrand.NewWeightedReservoir[int](-1) })
+}
+
+func ExampleWeightedReservoir() {
+	// sample 2 log lines from a stream, favoring the most severe ones.
+	type logLine struct {
+		msg      string
+		severity float64
+	}
+	lines := []logLine{{"started", 1}, {"disk almost full", 5}, {"request served", 1}, {"crash", 10}}
+	reservoir := xrand.NewWeightedReservoir[string](2)
+	for _, line := range lines {
+		reservoir.Offer(line.msg, line.severity)
+	}
+	fmt.Println(reservoir.Sample())
+}
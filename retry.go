@@ -0,0 +1,98 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Retry calls fn until it succeeds (returns nil error), up to attempts times.
+// Between attempts it sleeps for a jittered exponential backoff: base, 2*base, 4*base, ...,
+// each altered with [Jitter]'s default factor.
+// It returns the last error fn returned, if all attempts failed, or context's error
+// if the context got cancelled meanwhile.
+// If the context is already done, its error is returned without calling fn,
+// otherwise fn is called at least once (attempts < 1 is treated as 1).
+// If base <= 0, attempts are made without delay.
+func Retry(ctx context.Context, attempts int, base time.Duration, fn func() error) error {
+	var (
+		err   error
+		delay = base
+	)
+	for attempt := 1; ; attempt++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err = fn(); err == nil || attempt >= attempts {
+			return err
+		}
+
+		if delay > 0 {
+			if ctxErr := sleep(ctx, Jitter(delay)); ctxErr != nil {
+				return ctxErr
+			}
+			if delay <= math.MaxInt64/2 { // avoid overflow
+				delay *= 2
+			}
+		}
+	}
+}
+
+// BackoffSchedule returns the full schedule of delays of an exponential backoff, for given no. of attempts,
+// useful to log the planned retries, or for tests.
+// The first delay is base, each next one is the previous multiplied by multiplier, capped at max;
+// each delay is then altered with a random maxFactor, see [Jitter], thus a delay is at most max*(1+maxFactor).
+// If maxFactor is <= 0.0, a suggested default value will be chosen.
+// If attempts <= 0, an empty slice is returned.
+// It panics if base <= 0, max < base or multiplier < 1.
+func BackoffSchedule(attempts int, base, max time.Duration, multiplier, maxFactor float64) []time.Duration {
+	if base <= 0 || max < base || !(multiplier >= 1) { // Note: negated condition also catches NaN.
+		panic("invalid argument to BackoffSchedule")
+	}
+	if attempts <= 0 {
+		return []time.Duration{}
+	}
+
+	var (
+		schedule = make([]time.Duration, attempts)
+		delay    = float64(base)
+	)
+	for i := range schedule {
+		schedule[i] = Jitter(time.Duration(delay), maxFactor)
+		delay = math.Min(delay*multiplier, float64(max))
+	}
+
+	return schedule
+}
+
+// SleepJitter sleeps for base altered with a random factor, see [Jitter], or until
+// the context gets cancelled, whichever comes first.
+// It returns context's error if the context got cancelled before the sleep ended, nil otherwise.
+// If base <= 0, it does not sleep, just reports context's error.
+// If maxFactor is <= 0.0, a suggested default value will be chosen.
+func SleepJitter(ctx context.Context, base time.Duration, maxFactor ...float64) error {
+	if base <= 0 {
+		return ctx.Err()
+	}
+
+	return sleep(ctx, Jitter(base, maxFactor...))
+}
+
+// sleep sleeps for duration, or until the context gets cancelled, returning context's error in this case.
+// The underlying timer is stopped on cancellation, releasing its resources.
+func sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	select {
+	case <-ctx.Done():
+		timer.Stop()
+
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
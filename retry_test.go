@@ -0,0 +1,334 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/actforgood/xrand"
+)
+
+func TestRetry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success on first try", testRetrySuccessOnFirstTry)
+	t.Run("success after a few failures", testRetrySuccessAfterFailures)
+	t.Run("attempts exhausted", testRetryExhausted)
+	t.Run("context cancelled during backoff", testRetryContextCancelled)
+	t.Run("context already cancelled", testRetryContextAlreadyCancelled)
+}
+
+func testRetrySuccessOnFirstTry(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	calls := 0
+	fn := func() error {
+		calls++
+
+		return nil
+	}
+
+	// act
+	err := xrand.Retry(context.Background(), 5, time.Hour, fn)
+
+	// assert
+	assertNil(t, err)
+	assertEqual(t, 1, calls)
+}
+
+func testRetrySuccessAfterFailures(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	calls := 0
+	fn := func() error {
+		calls++
+		if calls < 4 {
+			return fmt.Errorf("failure #%d", calls)
+		}
+
+		return nil
+	}
+
+	// act
+	err := xrand.Retry(context.Background(), 5, time.Millisecond, fn)
+
+	// assert
+	assertNil(t, err)
+	assertEqual(t, 4, calls)
+}
+
+func testRetryExhausted(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		calls   = 0
+		lastErr error
+		fn      = func() error {
+			calls++
+			lastErr = fmt.Errorf("failure #%d", calls)
+
+			return lastErr
+		}
+	)
+
+	// act
+	err := xrand.Retry(context.Background(), 3, time.Millisecond, fn)
+
+	// assert
+	assertEqual(t, 3, calls)
+	assertTrue(t, err != nil && err == lastErr)
+}
+
+func testRetryContextCancelled(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		calls       = 0
+		ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+		fn          = func() error {
+			calls++
+
+			return errors.New("failure")
+		}
+	)
+	defer cancel()
+	start := time.Now()
+
+	// act
+	err := xrand.Retry(ctx, 5, time.Hour, fn)
+
+	// assert
+	assertTrue(t, errors.Is(err, context.DeadlineExceeded))
+	assertEqual(t, 1, calls)
+	assertTrue(t, time.Since(start) < time.Second)
+}
+
+func testRetryContextAlreadyCancelled(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		calls       = 0
+		ctx, cancel = context.WithCancel(context.Background())
+		fn          = func() error {
+			calls++
+
+			return nil
+		}
+	)
+	cancel()
+
+	// act
+	err := xrand.Retry(ctx, 5, time.Millisecond, fn)
+
+	// assert
+	assertTrue(t, errors.Is(err, context.Canceled))
+	assertEqual(t, 0, calls)
+}
+
+func TestBackoffSchedule(t *testing.T) {
+	t.Parallel()
+
+	t.Run("length and bounds", testBackoffScheduleBounds)
+	t.Run("growth is geometric until capped", testBackoffScheduleGeometric)
+	t.Run("no attempts", testBackoffScheduleNoAttempts)
+	t.Run("panics for invalid arguments", testBackoffSchedulePanics)
+}
+
+func testBackoffScheduleBounds(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		base      = 100 * time.Millisecond
+		max       = 5 * time.Second
+		maxFactor = 0.3
+		upper     = time.Duration(float64(max) * (1 + maxFactor))
+	)
+
+	for i := 0; i < 100; i++ {
+		// act
+		result := xrand.BackoffSchedule(20, base, max, 2, maxFactor)
+
+		// assert
+		assertEqual(t, 20, len(result))
+		for _, delay := range result {
+			assertTrue(t, delay > 0)
+			assertTrue(t, delay <= upper)
+		}
+		assertTrue(t, result[19] >= time.Duration(float64(max)*(1-maxFactor))) // capped, not overflowed
+	}
+}
+
+func testBackoffScheduleGeometric(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		base      = 10 * time.Millisecond
+		max       = time.Second
+		maxFactor = 0.01 // small jitter, to observe the growth
+	)
+
+	// act
+	result := xrand.BackoffSchedule(10, base, max, 3, maxFactor)
+
+	// assert
+	expected := []time.Duration{
+		10 * time.Millisecond, 30 * time.Millisecond, 90 * time.Millisecond, 270 * time.Millisecond,
+		810 * time.Millisecond, time.Second, time.Second, time.Second, time.Second, time.Second,
+	}
+	for idx, delay := range result {
+		deviation := math.Abs(float64(delay-expected[idx])) / float64(expected[idx])
+		assertTrue(t, deviation <= maxFactor)
+	}
+	for idx := 1; idx < 5; idx++ { // pre-cap ratio ~ multiplier
+		ratio := float64(result[idx]) / float64(result[idx-1])
+		assertTrue(t, ratio > 2.9 && ratio < 3.1)
+	}
+}
+
+func testBackoffScheduleNoAttempts(t *testing.T) {
+	t.Parallel()
+
+	// act
+	result1 := xrand.BackoffSchedule(0, time.Second, time.Minute, 2, 0.2)
+	result2 := xrand.BackoffSchedule(-1, time.Second, time.Minute, 2, 0.2)
+
+	// assert
+	assertEqual(t, 0, len(result1))
+	assertEqual(t, 0, len(result2))
+}
+
+func testBackoffSchedulePanics(t *testing.T) {
+	t.Parallel()
+
+	// act & assert
+	assertPanics(t, func() { _ = xrand.BackoffSchedule(3, 0, time.Minute, 2, 0.2) })
+	assertPanics(t, func() { _ = xrand.BackoffSchedule(3, time.Minute, time.Second, 2, 0.2) })
+	assertPanics(t, func() { _ = xrand.BackoffSchedule(3, time.Second, time.Minute, 0.5, 0.2) })
+	assertPanics(t, func() { _ = xrand.BackoffSchedule(3, time.Second, time.Minute, math.NaN(), 0.2) })
+}
+
+func TestSleepJitter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sleeps the jittered duration", testSleepJitterSleeps)
+	t.Run("context cancelled during sleep", testSleepJitterContextCancelled)
+	t.Run("context already cancelled", testSleepJitterContextAlreadyCancelled)
+	t.Run("non-positive base", testSleepJitterNonPositiveBase)
+}
+
+func testSleepJitterSleeps(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 5; i++ {
+		// arrange
+		start := time.Now()
+
+		// act
+		err := xrand.SleepJitter(context.Background(), 20*time.Millisecond, 0.5)
+
+		// assert
+		elapsed := time.Since(start)
+		assertNil(t, err)
+		assertTrue(t, elapsed >= 10*time.Millisecond)                    // jitter = [-10ms, 10ms)
+		assertTrue(t, elapsed < 30*time.Millisecond+50*time.Millisecond) // allow scheduling delays
+	}
+}
+
+func testSleepJitterContextCancelled(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+
+	// act
+	err := xrand.SleepJitter(ctx, time.Hour)
+
+	// assert
+	assertTrue(t, errors.Is(err, context.DeadlineExceeded))
+	assertTrue(t, time.Since(start) < time.Second)
+}
+
+func testSleepJitterContextAlreadyCancelled(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+
+	// act
+	err := xrand.SleepJitter(ctx, time.Hour, 0.1)
+
+	// assert
+	assertTrue(t, errors.Is(err, context.Canceled))
+	assertTrue(t, time.Since(start) < time.Second)
+}
+
+func testSleepJitterNonPositiveBase(t *testing.T) {
+	t.Parallel()
+
+	// act
+	err := xrand.SleepJitter(context.Background(), 0)
+
+	// assert
+	assertNil(t, err)
+
+	// arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// act
+	err = xrand.SleepJitter(ctx, -time.Second)
+
+	// assert
+	assertTrue(t, errors.Is(err, context.Canceled))
+}
+
+func ExampleRetry() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// call a flaky operation up to 5 times, with a jittered backoff of ~100ms, ~200ms, ~400ms, ...
+	err := xrand.Retry(ctx, 5, 100*time.Millisecond, func() error {
+		return nil // your flaky operation here
+	})
+	fmt.Println(err)
+}
+
+func ExampleSleepJitter() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// wait ~100ms (+/- 50%) before polling again, unless shutting down.
+	if err := xrand.SleepJitter(ctx, 100*time.Millisecond, 0.5); err != nil {
+		fmt.Println("stopped polling:", err)
+
+		return
+	}
+	fmt.Println("polling")
+}
+
+func ExampleBackoffSchedule() {
+	// log the planned retries: ~100ms, ~200ms, ~400ms, ~800ms, ~1s.
+	schedule := xrand.BackoffSchedule(5, 100*time.Millisecond, time.Second, 2, 0.1)
+	for attempt, delay := range schedule {
+		fmt.Printf("retry #%d in %v\n", attempt+1, delay)
+	}
+}
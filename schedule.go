@@ -0,0 +1,146 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// SpreadOverWindow returns count random offsets, uniformly distributed within [0,window),
+// sorted ascending. It is useful to spread jobs over a time window, so they do not align,
+// for example scheduling 100 jobs over the next hour.
+// If count <= 0 or window <= 0, an empty slice is returned.
+func SpreadOverWindow(count int, window time.Duration) []time.Duration {
+	if count <= 0 || window <= 0 {
+		return []time.Duration{}
+	}
+
+	offsets := make([]time.Duration, count)
+	for i := range offsets {
+		offsets[i] = time.Duration(globalRand.Int63n(int64(window)))
+	}
+	sort.Slice(offsets, func(i, j int) bool {
+		return offsets[i] < offsets[j]
+	})
+
+	return offsets
+}
+
+// DurationLogUniform returns a random duration in range [min,max), uniformly distributed in log-space,
+// so that each order of magnitude is equally likely: between 10ms and 10s, a result is
+// as likely to be in [10ms,100ms) as in [1s,10s), while a linear uniform distribution
+// would return a value in [1s,10s) 90% of the times.
+// It is useful to choose timeouts / delays across orders of magnitude.
+// It panics if min <= 0 or max <= min.
+func DurationLogUniform(min, max time.Duration) time.Duration {
+	if min <= 0 || max <= min {
+		panic("invalid argument to DurationLogUniform")
+	}
+
+	var (
+		logMin = math.Log(float64(min))
+		logMax = math.Log(float64(max))
+		d      = math.Exp(logMin + Float64()*(logMax-logMin))
+	)
+	// float rounding errors (checked before converting, as a float above max int64 does not convert well).
+	if d >= float64(max) {
+		return max - 1
+	}
+	if result := time.Duration(d); result > min {
+		return result
+	}
+
+	return min
+}
+
+// DelayFunc returns a function which, when called, sleeps a random duration in range [min,max),
+// picked independently on each call.
+// It is handy to wrap around fake dependencies, for exercising timeout paths in tests.
+// It panics if min < 0 or max <= min.
+func DelayFunc(min, max time.Duration) func() {
+	if min < 0 || max <= min {
+		panic("invalid argument to DelayFunc")
+	}
+
+	return func() {
+		timeSleep(durationBetween(min, max))
+	}
+}
+
+// durationBetween returns a random duration in range [min,max).
+// Range is expected to be valid, with min >= 0.
+func durationBetween(min, max time.Duration) time.Duration {
+	return min + time.Duration(globalRand.Int63n(int64(max-min)))
+}
+
+// JitterTicker is like a [time.Ticker], holding a channel that delivers "ticks" of a clock,
+// but each interval between ticks is independently altered with [Jitter].
+// It is useful to spread periodic work across a fleet.
+type JitterTicker struct {
+	// C is the channel on which the ticks are delivered.
+	// As with time.Ticker, ticks are dropped to make up for slow receivers.
+	C <-chan time.Time
+
+	stop     chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+// NewJitterTicker returns a new JitterTicker, whose ticks are delivered at interval,
+// altered with a random maxFactor, see [Jitter].
+// If maxFactor is <= 0.0, a suggested default value will be chosen.
+// Stop the ticker to release associated resources.
+// It panics if interval <= 0.
+func NewJitterTicker(interval time.Duration, maxFactor float64) *JitterTicker {
+	if interval <= 0 {
+		panic("non-positive interval for NewJitterTicker")
+	}
+
+	var (
+		c      = make(chan time.Time, 1)
+		ticker = &JitterTicker{
+			C:       c,
+			stop:    make(chan struct{}),
+			stopped: make(chan struct{}),
+		}
+	)
+	go ticker.run(c, interval, maxFactor)
+
+	return ticker
+}
+
+// run delivers the ticks, until the ticker is stopped.
+func (ticker *JitterTicker) run(c chan<- time.Time, interval time.Duration, maxFactor float64) {
+	defer close(ticker.stopped)
+
+	for {
+		timer := time.NewTimer(Jitter(interval, maxFactor))
+		select {
+		case <-ticker.stop:
+			timer.Stop()
+
+			return
+		case tick := <-timer.C:
+			select {
+			case c <- tick:
+			default: // drop the tick, receiver is slow.
+			}
+		}
+	}
+}
+
+// Stop turns off the ticker, and waits for its goroutine to end.
+// After Stop, no more ticks will be sent. Stop does not close the channel, as with time.Ticker.
+// It is safe to call Stop multiple times.
+func (ticker *JitterTicker) Stop() {
+	ticker.stopOnce.Do(func() {
+		close(ticker.stop)
+	})
+	<-ticker.stopped
+}
@@ -0,0 +1,345 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"runtime"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/actforgood/xrand"
+)
+
+func TestSpreadOverWindow(t *testing.T) {
+	t.Parallel()
+
+	t.Run("offsets are sorted and within the window", testSpreadOverWindowRange)
+	t.Run("offsets are uniform", testSpreadOverWindowIsUniform)
+	t.Run("empty result", testSpreadOverWindowEmpty)
+}
+
+func testSpreadOverWindowRange(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject = xrand.SpreadOverWindow
+		window  = time.Hour
+	)
+
+	for i := 0; i < 100; i++ {
+		// act
+		result := subject(100, window)
+
+		// assert
+		assertEqual(t, 100, len(result))
+		assertTrue(t, sort.SliceIsSorted(result, func(i, j int) bool { return result[i] < result[j] }))
+		for _, offset := range result {
+			assertTrue(t, offset >= 0 && offset < window)
+		}
+	}
+}
+
+func testSpreadOverWindowIsUniform(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const (
+		count   = 100000
+		buckets = 10
+	)
+	var (
+		window      = time.Minute
+		counts      = make([]int, buckets)
+		totalOffset time.Duration
+		totalGap    time.Duration
+	)
+
+	// act
+	result := xrand.SpreadOverWindow(count, window)
+
+	// assert
+	for idx, offset := range result {
+		counts[int(offset*buckets/window)]++
+		totalOffset += offset
+		if idx > 0 {
+			totalGap += offset - result[idx-1]
+		}
+	}
+	assertUniform(t, counts, count, 0.05)
+	meanOffset := totalOffset / count
+	assertTrue(t, meanOffset > window*45/100 && meanOffset < window*55/100)
+	meanGap := totalGap / (count - 1)
+	expectedGap := window / count
+	assertTrue(t, meanGap > expectedGap*9/10 && meanGap < expectedGap*11/10)
+}
+
+func testSpreadOverWindowEmpty(t *testing.T) {
+	t.Parallel()
+
+	// act & assert
+	assertEqual(t, 0, len(xrand.SpreadOverWindow(0, time.Hour)))
+	assertEqual(t, 0, len(xrand.SpreadOverWindow(-1, time.Hour)))
+	assertEqual(t, 0, len(xrand.SpreadOverWindow(10, 0)))
+}
+
+func TestDurationLogUniform(t *testing.T) {
+	t.Parallel()
+
+	t.Run("result is in range", testDurationLogUniformRange)
+	t.Run("decades are equally likely", testDurationLogUniformDecades)
+	t.Run("panics for invalid arguments", testDurationLogUniformPanics)
+}
+
+func testDurationLogUniformRange(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	tests := [...]struct {
+		min time.Duration
+		max time.Duration
+	}{
+		{min: time.Millisecond, max: time.Hour},
+		{min: 1, max: 2},
+		{min: 1, max: math.MaxInt64},
+		{min: time.Second, max: time.Second + 10},
+	}
+
+	for _, test := range tests {
+		for i := 0; i < 1000; i++ {
+			// act
+			result := xrand.DurationLogUniform(test.min, test.max)
+
+			// assert
+			assertTrue(t, result >= test.min)
+			assertTrue(t, result < test.max)
+		}
+	}
+}
+
+func testDurationLogUniformDecades(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 30000
+	counts := make([]int, 3)
+
+	for i := 0; i < iterations; i++ {
+		// act
+		result := xrand.DurationLogUniform(10*time.Millisecond, 10*time.Second)
+
+		// assert
+		switch {
+		case result < 100*time.Millisecond:
+			counts[0]++
+		case result < time.Second:
+			counts[1]++
+		default:
+			counts[2]++
+		}
+	}
+	assertUniform(t, counts, iterations, 0.05)
+}
+
+func testDurationLogUniformPanics(t *testing.T) {
+	t.Parallel()
+
+	// act & assert
+	assertPanics(t, func() { _ = xrand.DurationLogUniform(0, time.Second) })
+	assertPanics(t, func() { _ = xrand.DurationLogUniform(-time.Second, time.Second) })
+	assertPanics(t, func() { _ = xrand.DurationLogUniform(time.Second, time.Second) })
+	assertPanics(t, func() { _ = xrand.DurationLogUniform(time.Minute, time.Second) })
+}
+
+func TestDelayFunc(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		min     = 10 * time.Millisecond
+		max     = 20 * time.Millisecond
+		subject = xrand.DelayFunc(min, max)
+	)
+
+	for i := 0; i < 3; i++ {
+		// act
+		start := time.Now()
+		subject()
+		elapsed := time.Since(start)
+
+		// assert
+		assertTrue(t, elapsed >= min)
+	}
+
+	t.Run("panics for invalid range", func(t *testing.T) {
+		t.Parallel()
+
+		assertPanics(t, func() { _ = xrand.DelayFunc(-time.Second, time.Second) })
+		assertPanics(t, func() { _ = xrand.DelayFunc(time.Second, time.Second) })
+		assertPanics(t, func() { _ = xrand.DelayFunc(2*time.Second, time.Second) })
+	})
+}
+
+func TestDelayFuncDelays(t *testing.T) { // Note: not parallel, as it replaces the global sleep function.
+	// arrange
+	const iterations = 100000
+	var (
+		min      = 100 * time.Millisecond
+		max      = 200 * time.Millisecond
+		slept    []time.Duration
+		subject1 = xrand.DelayFunc(min, max)
+		subject2 = xrand.DelayFunc(min, max)
+		counts   = make([]int, 10)
+	)
+	defer xrand.SetSleep(func(d time.Duration) { slept = append(slept, d) })()
+
+	for i := 0; i < iterations/2; i++ {
+		// act
+		subject1()
+		subject2()
+	}
+
+	// assert
+	assertEqual(t, iterations, len(slept))
+	distinct := 0
+	for i, d := range slept {
+		assertTrue(t, d >= min && d < max)
+		counts[(d-min)*10/(max-min)]++
+		if i%2 == 1 && d != slept[i-1] { // closures' delays are independent
+			distinct++
+		}
+	}
+	assertTrue(t, distinct > iterations/2-10)
+	assertUniform(t, counts, iterations, 0.05)
+}
+
+func TestJitterTicker(t *testing.T) {
+	t.Parallel()
+
+	t.Run("intervals vary within the jitter band", testJitterTickerIntervals)
+	t.Run("stop halts the ticks", testJitterTickerStop)
+	t.Run("panics for non-positive interval", testJitterTickerPanics)
+}
+
+func testJitterTickerIntervals(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		interval  = 20 * time.Millisecond
+		subject   = xrand.NewJitterTicker(interval, 0.5)
+		ticks     = make([]time.Time, 0, 11)
+		intervals = make(map[time.Duration]struct{})
+	)
+	defer subject.Stop()
+
+	// act
+	for len(ticks) < cap(ticks) {
+		ticks = append(ticks, <-subject.C)
+	}
+
+	// assert
+	for i := 1; i < len(ticks); i++ {
+		elapsed := ticks[i].Sub(ticks[i-1])
+		assertTrue(t, elapsed >= 10*time.Millisecond)
+		assertTrue(t, elapsed < 30*time.Millisecond+50*time.Millisecond) // allow scheduling delays
+		intervals[elapsed] = struct{}{}
+	}
+	assertTrue(t, len(intervals) > 1)
+}
+
+func testJitterTickerStop(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	subject := xrand.NewJitterTicker(time.Millisecond, 0.2)
+	<-subject.C
+
+	// act
+	subject.Stop()
+	subject.Stop() // multiple calls are safe
+
+	// assert
+	select { // drain a possible tick delivered before stopping
+	case <-subject.C:
+	default:
+	}
+	select {
+	case <-subject.C:
+		t.Error("no tick should be delivered after Stop")
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func testJitterTickerPanics(t *testing.T) {
+	t.Parallel()
+
+	// act & assert
+	assertPanics(t, func() { _ = xrand.NewJitterTicker(0, 0.2) })
+	assertPanics(t, func() { _ = xrand.NewJitterTicker(-time.Second, 0.2) })
+}
+
+func TestJitterTickerDoesNotLeakGoroutines(t *testing.T) { // Note: not parallel, to have a stable no. of goroutines.
+	// arrange
+	goroutinesBefore := runtime.NumGoroutine()
+	tickers := make([]*xrand.JitterTicker, 10)
+	for i := range tickers {
+		tickers[i] = xrand.NewJitterTicker(time.Millisecond, 0.2)
+	}
+	assertTrue(t, runtime.NumGoroutine() >= goroutinesBefore+len(tickers))
+
+	// act
+	for _, ticker := range tickers {
+		ticker.Stop()
+	}
+
+	// assert
+	assertTrue(t, runtime.NumGoroutine() <= goroutinesBefore)
+}
+
+func ExampleSpreadOverWindow() {
+	// schedule 5 jobs over the next hour, so they do not run all at once.
+	now := time.Now()
+	for _, offset := range xrand.SpreadOverWindow(5, time.Hour) {
+		fmt.Println(now.Add(offset).Format(time.Kitchen))
+	}
+}
+
+func ExampleDurationLogUniform() {
+	// choose a timeout for a chaos test, equally likely to be ~10ms, ~100ms, ~1s.
+	timeout := xrand.DurationLogUniform(10*time.Millisecond, 10*time.Second)
+	fmt.Println(timeout)
+}
+
+func ExampleDelayFunc() {
+	// a fake dependency, responding slowly, to exercise the timeout path.
+	delay := xrand.DelayFunc(50*time.Millisecond, 150*time.Millisecond)
+	fetch := func(ctx context.Context) error {
+		delay()
+
+		return ctx.Err()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	fmt.Println(fetch(ctx))
+	// Output: context deadline exceeded
+}
+
+func ExampleJitterTicker() {
+	// poll every ~1s, slightly altered +/- 10%.
+	ticker := xrand.NewJitterTicker(time.Second, 0.1)
+	defer ticker.Stop()
+
+	for i := 0; i < 3; i++ {
+		tick := <-ticker.C
+		fmt.Println("polling at", tick)
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"unsafe"
+)
+
+// ErrAlphabetNotPowerOfTwo is returned when an alphabet's length is expected to be a power of two, but it's not.
+var ErrAlphabetNotPowerOfTwo = errors.New("xrand: alphabet length must be a power of two, at least 2")
+
+// SecureStringConstantTime generates a cryptographically secure random string
+// of length n with letters from the alphabet.
+// Alphabet's length must be a power of two (2, 4, 8, 16, 32, 64, ...), otherwise
+// [ErrAlphabetNotPowerOfTwo] is returned.
+// Unlike [String], no rejection sampling is needed: every letter consumes a fixed
+// number of random bits, so the generation's timing does not depend on the random data.
+// An error is returned also if reading from crypto/rand fails.
+func SecureStringConstantTime(n int, alphabet string) (string, error) {
+	if len(alphabet) < 2 || len(alphabet)&(len(alphabet)-1) != 0 {
+		return "", ErrAlphabetNotPowerOfTwo
+	}
+
+	var (
+		alphabetIdxBits      = countBits(len(alphabet))              // the no. of bits an index in alphabet consumes.
+		alphabetIdxMask uint = 1<<alphabetIdxBits - 1                // 1...1b bits, of length alphabetIdxBits
+		entropy              = make([]byte, (n*alphabetIdxBits+7)/8) // all the random bits needed.
+		b                    = make([]byte, n)
+	)
+	if err := readCryptoRand(entropy); err != nil {
+		return "", err
+	}
+
+	var (
+		bits       uint // buffered random bits
+		bitsLen    int  // no. of buffered random bits
+		entropyIdx int  // next entropy byte to buffer
+	)
+	for i := range b {
+		for bitsLen < alphabetIdxBits {
+			bits |= uint(entropy[entropyIdx]) << bitsLen
+			entropyIdx++
+			bitsLen += 8
+		}
+		b[i] = alphabet[bits&alphabetIdxMask]
+		bits >>= alphabetIdxBits
+		bitsLen -= alphabetIdxBits
+	}
+
+	return *(*string)(unsafe.Pointer(&b)), nil
+}
+
+// maxSecureStringEntropySize is the max no. of random bytes [SecureString] reads from crypto/rand at once.
+const maxSecureStringEntropySize = 512
+
+// SecureString generates a cryptographically secure random string of length n with letters from the alphabet.
+// Alphabet is optional and defaults to [AlphanumAlphabet] if not provided.
+// Like [String], each letter is drawn from the minimum no. of random bits covering the alphabet's length,
+// and draws falling outside of it are rejected, thus alphabets of any length can be used.
+// Random bytes are read in bulk, in a buffer large enough for the expected no. of draws
+// (see [StringRejectionRate]), which is refilled only when exhausted.
+// An error is returned if reading from crypto/rand fails.
+func SecureString(n int, alphabet ...string) (string, error) {
+	var (
+		a               = alphabetOrDefault(alphabet)
+		alphabetIdxBits = countBits(len(a)) // the no. of bits an index in alphabet consumes.
+		b               = make([]byte, n)
+	)
+	if alphabetIdxBits == 0 { // single letter alphabet, no randomness needed.
+		for i := range b {
+			b[i] = a[0]
+		}
+
+		return *(*string)(unsafe.Pointer(&b)), nil
+	}
+
+	var (
+		alphabetIdxMask uint = 1<<alphabetIdxBits - 1 // 1...1b bits, of length alphabetIdxBits
+		expectedBits         = float64(n*alphabetIdxBits) / (1 - StringRejectionRate(a))
+		entropySize          = int(expectedBits/8) + 1
+	)
+	if entropySize > maxSecureStringEntropySize {
+		entropySize = maxSecureStringEntropySize
+	}
+
+	var (
+		entropy    = make([]byte, entropySize)
+		entropyIdx = len(entropy) // next entropy byte to buffer, the buffer is initially exhausted.
+		bits       uint           // buffered random bits
+		bitsLen    int            // no. of buffered random bits
+	)
+	for i := 0; i < n; {
+		for bitsLen < alphabetIdxBits {
+			if entropyIdx == len(entropy) {
+				if err := readCryptoRand(entropy); err != nil {
+					return "", err
+				}
+				entropyIdx = 0
+			}
+			bits |= uint(entropy[entropyIdx]) << bitsLen
+			entropyIdx++
+			bitsLen += 8
+		}
+		if alphabetIdx := int(bits & alphabetIdxMask); alphabetIdx < len(a) {
+			b[i] = a[alphabetIdx]
+			i++
+		}
+		bits >>= alphabetIdxBits
+		bitsLen -= alphabetIdxBits
+	}
+
+	return *(*string)(unsafe.Pointer(&b)), nil
+}
+
+// SecureShuffle shuffles items in place, using crypto/rand for the Fisher-Yates swaps,
+// making the resulting order unpredictable, suitable for security sensitive orderings.
+// An error is returned if reading from crypto/rand fails, in which case items
+// may be partially shuffled.
+func SecureShuffle[T any](items []T) error {
+	for i := len(items) - 1; i > 0; i-- {
+		j, err := secureUint64n(uint64(i) + 1)
+		if err != nil {
+			return err
+		}
+		items[i], items[j] = items[j], items[i]
+	}
+
+	return nil
+}
+
+// secureUint64n generates a cryptographically secure random uint64 in range [0,n), n > 0.
+// It uses rejection sampling to avoid modulo bias, see [SecureIntnUnbiased].
+func secureUint64n(n uint64) (uint64, error) {
+	var (
+		b       [8]byte
+		ceiling = math.MaxUint64 - (math.MaxUint64%n+1)%n // [0, ceiling] contains a multiple of n values.
+	)
+	for {
+		if err := readCryptoRand(b[:]); err != nil {
+			return 0, err
+		}
+		if v := binary.LittleEndian.Uint64(b[:]); v <= ceiling {
+			return v % n, nil
+		}
+	}
+}
+
+// SecureIntnUnbiased generates a cryptographically secure random integer in range [0,n),
+// with an exactly uniform distribution.
+// A naive "random bytes mod n" approach is biased towards lower values whenever n does not
+// divide the random values' range (for example, 256 mod 7 = 4, so with a random byte,
+// values 0..3 would be more likely than 4..6). To avoid that, rejection sampling is used:
+// random 64 bits values above the largest multiple of n that fits in 64 bits are discarded,
+// and a new value is read. The probability of a retry is always < 1/2.
+// An error is returned if reading from crypto/rand fails.
+// It panics if n <= 0.
+func SecureIntnUnbiased(n int) (int, error) {
+	if n <= 0 {
+		panic("invalid argument to SecureIntnUnbiased")
+	}
+	v, err := secureUint64n(uint64(n))
+
+	return int(v), err
+}
+
+// SecureFloat64 generates a cryptographically secure random float64 in range [0.0, 1.0),
+// suitable for unpredictable sampling decisions.
+// 53 random bits (the precision of a float64's mantissa) are read and divided by 2^53,
+// so that every result is one of the 2^53 equally likely, equally spaced values k/2^53.
+// Using more bits would lead to rounding, which can produce 1.0 and biases the distribution.
+// An error is returned if reading from crypto/rand fails.
+func SecureFloat64() (float64, error) {
+	var b [8]byte
+	if err := readCryptoRand(b[:]); err != nil {
+		return 0, err
+	}
+	mantissa := binary.LittleEndian.Uint64(b[:]) >> 11 // keep 53 bits
+
+	return float64(mantissa) / (1 << 53), nil
+}
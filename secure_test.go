@@ -0,0 +1,601 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/actforgood/xrand"
+)
+
+func TestSecureStringConstantTime(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", testSecureStringConstantTimeSuccess)
+	t.Run("error - alphabet length is not a power of two", testSecureStringConstantTimeInvalidAlphabet)
+}
+
+func testSecureStringConstantTimeSuccess(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject = xrand.SecureStringConstantTime
+		tests   = [...]struct {
+			name          string
+			inputLength   int
+			inputAlphabet string
+			expectedReg   *regexp.Regexp
+		}{
+			{
+				name:          "len = 10, alphabet = 01",
+				inputLength:   10,
+				inputAlphabet: "01",
+				expectedReg:   regexp.MustCompile(`^[01]{10}$`),
+			},
+			{
+				name:          "len = 33, alphabet = 0123456789abcdef",
+				inputLength:   33,
+				inputAlphabet: "0123456789abcdef",
+				expectedReg:   regexp.MustCompile(`^[0-9a-f]{33}$`),
+			},
+			{
+				name:          "len = 150, alphabet = a-zA-Z0-9-_",
+				inputLength:   150,
+				inputAlphabet: "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_",
+				expectedReg:   regexp.MustCompile(`^[a-zA-Z0-9\-_]{150}$`),
+			},
+			{
+				name:          "len = 7, alphabet of 8 letters",
+				inputLength:   7,
+				inputAlphabet: "abcdefgh",
+				expectedReg:   regexp.MustCompile(`^[a-h]{7}$`),
+			},
+			{
+				name:          "len = 0",
+				inputLength:   0,
+				inputAlphabet: "abcd",
+				expectedReg:   regexp.MustCompile(`^$`),
+			},
+		}
+	)
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			for i := 0; i < 1000; i++ {
+				// act
+				result, err := subject(test.inputLength, test.inputAlphabet)
+
+				// assert
+				assertNil(t, err)
+				assertTrue(t, test.expectedReg.MatchString(result))
+			}
+		})
+	}
+}
+
+func testSecureStringConstantTimeInvalidAlphabet(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	subject := xrand.SecureStringConstantTime
+
+	for _, testData := range [...]string{"", "a", "abc", "abcdefghij", xrand.AlphanumAlphabet, strings.Repeat("a", 65)} {
+		alphabet := testData // capture range variable
+		t.Run(fmt.Sprintf("alphabet length = %d", len(alphabet)), func(t *testing.T) {
+			// act
+			result, err := subject(10, alphabet)
+
+			// assert
+			assertTrue(t, errors.Is(err, xrand.ErrAlphabetNotPowerOfTwo))
+			assertEqual(t, "", result)
+		})
+	}
+}
+
+func TestSecureShuffle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("result is a permutation", testSecureShuffleIsPermutation)
+	t.Run("permutations are uniform", testSecureShuffleIsUniform)
+}
+
+func testSecureShuffleIsPermutation(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject  = xrand.SecureShuffle[int]
+		items    = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+		original = append([]int(nil), items...)
+		changed  = false
+	)
+
+	for i := 0; i < 100; i++ {
+		// act
+		err := subject(items)
+
+		// assert
+		assertNil(t, err)
+		assertSamePermutation(t, original, items)
+		if !reflect.DeepEqual(original, items) {
+			changed = true
+		}
+	}
+	assertTrue(t, changed)
+
+	// empty & single element.
+	assertNil(t, subject(nil))
+	single := []int{1}
+	assertNil(t, subject(single))
+	assertEqual(t, 1, single[0])
+}
+
+func testSecureShuffleIsUniform(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 60000
+	var (
+		subject      = xrand.SecureShuffle[string]
+		permutations = map[string]int{"abc": 0, "acb": 1, "bac": 2, "bca": 3, "cab": 4, "cba": 5}
+		counts       = make([]int, len(permutations))
+	)
+
+	for i := 0; i < iterations; i++ {
+		items := []string{"a", "b", "c"}
+
+		// act
+		err := subject(items)
+
+		// assert
+		assertNil(t, err)
+		counts[permutations[strings.Join(items, "")]]++
+	}
+	assertUniform(t, counts, iterations, 0.05)
+}
+
+func TestSecureShuffleRejection(t *testing.T) { // Note: not parallel, as it replaces the global crypto reader.
+	// arrange
+	var (
+		items  = []int{1, 2, 3}
+		reader = bytes.NewReader(bytes.Join([][]byte{
+			bytes.Repeat([]byte{0xFF}, 8), // above the ceiling of [0,3), 2^64 - 2, rejected.
+			make([]byte, 8),               // j = 0, swaps 1 and 3.
+			bytes.Repeat([]byte{0xFF}, 8), // within the ceiling of [0,2), j = 1, no swap.
+		}, nil))
+	)
+	defer xrand.SetCryptoReader(reader)()
+
+	// act
+	err := xrand.SecureShuffle(items)
+
+	// assert
+	assertNil(t, err)
+	assertEqual(t, 0, reader.Len())
+	assertEqual(t, "[3 2 1]", fmt.Sprint(items))
+}
+
+func TestSecureIntnUnbiased(t *testing.T) {
+	t.Parallel()
+
+	t.Run("result is in range", testSecureIntnUnbiasedInRange)
+	t.Run("distribution is flat", testSecureIntnUnbiasedIsUniform)
+	t.Run("panics for n <= 0", testSecureIntnUnbiasedPanics)
+}
+
+func testSecureIntnUnbiasedInRange(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	subject := xrand.SecureIntnUnbiased
+
+	for _, testData := range [...]int{1, 7, 10, 256, 1000, 1<<31 - 1} {
+		n := testData // capture range variable
+		t.Run(fmt.Sprintf("[0,%d)", n), func(t *testing.T) {
+			for i := 0; i < 1000; i++ {
+				// act
+				result, err := subject(n)
+
+				// assert
+				assertNil(t, err)
+				assertTrue(t, result >= 0 && result < n)
+			}
+		})
+	}
+}
+
+func testSecureIntnUnbiasedIsUniform(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const (
+		n          = 7
+		iterations = 700000
+	)
+	// Note: with 64 bits draws, a plain modulo would bias values by ~2^-61 only, which no flatness
+	// check can detect; rejection of draws above the ceiling is checked by TestSecureIntnUnbiasedRejection.
+	var (
+		subject = xrand.SecureIntnUnbiased
+		counts  = make([]int, n)
+	)
+
+	for i := 0; i < iterations; i++ {
+		// act
+		result, err := subject(n)
+
+		// assert
+		assertNil(t, err)
+		counts[result]++
+	}
+	assertUniform(t, counts, iterations, 0.015)
+}
+
+func testSecureIntnUnbiasedPanics(t *testing.T) {
+	t.Parallel()
+
+	// act & assert
+	assertPanics(t, func() { _, _ = xrand.SecureIntnUnbiased(0) })
+	assertPanics(t, func() { _, _ = xrand.SecureIntnUnbiased(-1) })
+}
+
+func TestSecureIntnUnbiasedError(t *testing.T) { // Note: not parallel, as it replaces the global crypto reader.
+	// arrange
+	defer xrand.SetCryptoReader(errReader{})()
+
+	// act
+	_, err := xrand.SecureIntnUnbiased(7)
+
+	// assert
+	assertTrue(t, errors.Is(err, errEntropy))
+}
+
+func TestSecureIntnUnbiasedRejection(t *testing.T) { // Note: not parallel, as it replaces the global crypto reader.
+	// arrange
+	var (
+		second = []byte{10, 0, 0, 0, 0, 0, 0, 0} // 10, little endian.
+		reader = bytes.NewReader(append(bytes.Repeat([]byte{0xFF}, 8), second...))
+	)
+	defer xrand.SetCryptoReader(reader)()
+
+	// act
+	result, err := xrand.SecureIntnUnbiased(7) // ceiling is 2^64 - 3, 0xFF...FF is above it.
+
+	// assert
+	assertNil(t, err)
+	assertEqual(t, 3, result) // 10 % 7, from the second read.
+	assertEqual(t, 0, reader.Len())
+}
+
+func TestSecureShuffleError(t *testing.T) { // Note: not parallel, as it replaces the global crypto reader.
+	// arrange
+	defer xrand.SetCryptoReader(errReader{})()
+	items := []int{1, 2, 3}
+
+	// act
+	err := xrand.SecureShuffle(items)
+
+	// assert
+	assertTrue(t, errors.Is(err, errEntropy))
+}
+
+func TestSecureFloat64(t *testing.T) {
+	t.Parallel()
+
+	t.Run("result is in range", testSecureFloat64InRange)
+	t.Run("distribution is flat", testSecureFloat64IsUniform)
+}
+
+func testSecureFloat64InRange(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10000; i++ {
+		// act
+		result, err := xrand.SecureFloat64()
+
+		// assert
+		assertNil(t, err)
+		assertTrue(t, result >= 0 && result < 1)
+	}
+}
+
+func testSecureFloat64IsUniform(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const (
+		buckets    = 20
+		iterations = 200000
+	)
+	counts := make([]int, buckets)
+
+	for i := 0; i < iterations; i++ {
+		// act
+		result, err := xrand.SecureFloat64()
+
+		// assert
+		assertNil(t, err)
+		counts[int(result*buckets)]++
+	}
+	assertUniform(t, counts, iterations, 0.05)
+}
+
+func TestSecureFloat64Bounds(t *testing.T) { // Note: not parallel, as it replaces the global crypto reader.
+	// arrange
+	restore := xrand.SetCryptoReader(bytes.NewReader(bytes.Repeat([]byte{0xFF}, 8)))
+
+	// act
+	result, err := xrand.SecureFloat64()
+
+	// assert
+	assertNil(t, err)
+	assertEqual(t, 1-math.Pow(2, -53), result) // the largest value, still < 1
+	restore()
+
+	// arrange
+	defer xrand.SetCryptoReader(bytes.NewReader(make([]byte, 8)))()
+
+	// act
+	result, err = xrand.SecureFloat64()
+
+	// assert
+	assertNil(t, err)
+	assertEqual(t, 0.0, result)
+}
+
+func TestSecureFloat64Error(t *testing.T) { // Note: not parallel, as it replaces the global crypto reader.
+	// arrange
+	defer xrand.SetCryptoReader(errReader{})()
+
+	// act
+	result, err := xrand.SecureFloat64()
+
+	// assert
+	assertTrue(t, errors.Is(err, errEntropy))
+	assertEqual(t, 0.0, result)
+}
+
+func TestSecureString(t *testing.T) {
+	t.Parallel()
+
+	t.Run("letters are from alphabet", testSecureStringAlphabet)
+	t.Run("distribution is flat", testSecureStringIsUniform)
+}
+
+func testSecureStringAlphabet(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	tests := [...]struct {
+		name          string
+		inputLength   int
+		inputAlphabet []string
+		expectedReg   *regexp.Regexp
+	}{
+		{
+			name:        "default alphabet",
+			inputLength: 32,
+			expectedReg: regexp.MustCompile(`^[a-z0-9]{32}$`),
+		},
+		{
+			name:          "digits alphabet",
+			inputLength:   1000, // Note: more than the max entropy buffer, which gets refilled.
+			inputAlphabet: []string{xrand.DigitsAlphabet},
+			expectedReg:   regexp.MustCompile(`^[0-9]+$`),
+		},
+		{
+			name:          "power of two alphabet",
+			inputLength:   20,
+			inputAlphabet: []string{"0123456789abcdef"},
+			expectedReg:   regexp.MustCompile(`^[0-9a-f]{20}$`),
+		},
+		{
+			name:          "length just above a power of two",
+			inputLength:   20,
+			inputAlphabet: []string{"ABCDEFGHIJKLMNOPQ"},
+			expectedReg:   regexp.MustCompile(`^[A-Q]{20}$`),
+		},
+		{
+			name:          "single letter alphabet",
+			inputLength:   5,
+			inputAlphabet: []string{"x"},
+			expectedReg:   regexp.MustCompile(`^xxxxx$`),
+		},
+		{
+			name:        "len = 0",
+			inputLength: 0,
+			expectedReg: regexp.MustCompile(`^$`),
+		},
+	}
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			for i := 0; i < 100; i++ {
+				// act
+				result, err := xrand.SecureString(test.inputLength, test.inputAlphabet...)
+
+				// assert
+				assertNil(t, err)
+				assertEqual(t, test.inputLength, len(result))
+				assertTrue(t, test.expectedReg.MatchString(result))
+			}
+		})
+	}
+}
+
+func testSecureStringIsUniform(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const length = 100000
+	counts := make([]int, len(xrand.DigitsAlphabet))
+
+	// act
+	result, err := xrand.SecureString(length, xrand.DigitsAlphabet)
+
+	// assert
+	assertNil(t, err)
+	for _, char := range result {
+		counts[char-'0']++
+	}
+	assertUniform(t, counts, length, 0.05)
+}
+
+func TestSecureStringError(t *testing.T) { // Note: not parallel, as it replaces the global crypto reader.
+	// arrange
+	tests := [...]struct {
+		name        string
+		inputReader io.Reader
+	}{
+		{name: "first read fails", inputReader: errReader{}},
+		{
+			name:        "refill fails",
+			inputReader: io.MultiReader(bytes.NewReader(bytes.Repeat([]byte{0xFF}, 512)), errReader{}), // all rejected
+		},
+	}
+
+	for _, test := range tests {
+		restore := xrand.SetCryptoReader(test.inputReader)
+
+		// act
+		result, err := xrand.SecureString(1000, xrand.DigitsAlphabet)
+
+		// assert
+		restore()
+		assertTrue(t, errors.Is(err, errEntropy))
+		assertEqual(t, "", result)
+	}
+}
+
+// errEntropy is the error returned by errReader.
+var errEntropy = errors.New("intentionally triggered entropy error")
+
+// errReader is a reader which always fails.
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errEntropy
+}
+
+// assertSamePermutation checks that actual is a permutation of expected.
+// Returns successful assertion status.
+func assertSamePermutation[T comparable](t *testing.T, expected, actual []T) bool {
+	t.Helper()
+	if len(expected) != len(actual) {
+		t.Errorf("expected %d elements, but got %d", len(expected), len(actual))
+
+		return false
+	}
+	counts := make(map[T]int, len(expected))
+	for _, item := range expected {
+		counts[item]++
+	}
+	for _, item := range actual {
+		counts[item]--
+	}
+	for item, count := range counts {
+		if count != 0 {
+			t.Errorf("element %+v occurrences differ by %d", item, count)
+
+			return false
+		}
+	}
+
+	return true
+}
+
+func BenchmarkSecureStringConstantTime(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = xrand.SecureStringConstantTime(16, "0123456789abcdef")
+	}
+}
+
+func BenchmarkSecureString(b *testing.B) {
+	b.Run("bulk read", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = xrand.SecureString(32)
+		}
+	})
+	b.Run("per char read", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = xrand.SecureStringPerChar(32)
+		}
+	})
+}
+
+func ExampleSecureString() {
+	// generate an unpredictable password reset code.
+	code, err := xrand.SecureString(10, xrand.Base32CrockfordAlphabet)
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+	fmt.Println(code)
+}
+
+func ExampleSecureStringConstantTime() {
+	// generate a cryptographically secure random hex string of length 32.
+	token, err := xrand.SecureStringConstantTime(32, "0123456789abcdef")
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+	fmt.Println(token)
+}
+
+func ExampleSecureShuffle() {
+	// randomize the order of challenges, unpredictably.
+	challenges := []string{"challenge1", "challenge2", "challenge3"}
+	if err := xrand.SecureShuffle(challenges); err != nil {
+		fmt.Println(err)
+
+		return
+	}
+	fmt.Println(challenges)
+}
+
+func ExampleSecureIntnUnbiased() {
+	// roll a die, unpredictably and without modulo bias.
+	n, err := xrand.SecureIntnUnbiased(6)
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+	fmt.Println(n + 1)
+}
+
+func ExampleSecureFloat64() {
+	// unpredictably decide whether to audit a request, with a 1% probability.
+	p, err := xrand.SecureFloat64()
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+	if p < 0.01 {
+		fmt.Println("audit request")
+	}
+}
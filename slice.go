@@ -0,0 +1,203 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand
+
+import (
+	"errors"
+	"sort"
+)
+
+// SampleIndices returns k distinct random indices from [0,n), in random order.
+// It is useful to sample the same positions across multiple parallel slices.
+// If k >= n, a full random permutation of [0,n) is returned.
+// If k <= 0 or n <= 0, an empty slice is returned.
+func SampleIndices(n, k int) []int {
+	if n <= 0 || k <= 0 {
+		return []int{}
+	}
+	if k > n {
+		k = n
+	}
+
+	indices := make([]int, n)
+	for i := range indices {
+		indices[i] = i
+	}
+	// partial Fisher-Yates: only the first k positions get shuffled.
+	for i := 0; i < k; i++ {
+		j := i + globalRand.Intn(n-i)
+		indices[i], indices[j] = indices[j], indices[i]
+	}
+
+	return indices[:k:k]
+}
+
+var (
+	// ErrNotEnoughValues is returned when more distinct values are requested than a range holds.
+	ErrNotEnoughValues = errors.New("xrand: range holds fewer distinct values than requested")
+	// ErrInfeasibleGap is returned when the requested indices cannot be spaced by the minimum gap.
+	ErrInfeasibleGap = errors.New("xrand: not enough room for the indices to be spaced by the minimum gap")
+)
+
+// UniqueInts returns k distinct random integers from [min,max), in random order.
+// The strategy is chosen by density: if k is small relative to the range (at most half of it),
+// random values are drawn, rejecting the already seen ones, thus the memory used is proportional to k,
+// even for huge ranges; otherwise, the rejections would be too frequent, and
+// the range is partially shuffled instead, see [SampleIndices].
+// If k <= 0, an empty slice is returned.
+// It returns [ErrInvalidRange] if max <= min, [ErrNotEnoughValues] if k > max-min.
+func UniqueInts(min, max, k int) ([]int, error) {
+	if max <= min {
+		return nil, ErrInvalidRange
+	}
+	if k <= 0 {
+		return []int{}, nil
+	}
+	span := uint64(max) - uint64(min) // Note: correct even if max-min overflows int.
+	if uint64(k) > span {
+		return nil, ErrNotEnoughValues
+	}
+
+	if uint64(k) <= span/2 { // sparse, rejection sampling.
+		var (
+			values = make([]int, 0, k)
+			seen   = make(map[int]struct{}, k)
+		)
+		for len(values) < k {
+			v, _ := IntnBetweenSafe(min, max) // Note: never errors, range is valid.
+			if _, found := seen[v]; !found {
+				seen[v] = struct{}{}
+				values = append(values, v)
+			}
+		}
+
+		return values, nil
+	}
+
+	// dense, partial shuffle of the range, which has at most 2k values here.
+	values := SampleIndices(int(span), k)
+	for i := range values {
+		values[i] += min
+	}
+
+	return values, nil
+}
+
+// PickSpaced returns k random indices from [0,n), in ascending order, any two of them
+// being at least minGap apart, like ad slots which must not be adjacent (minGap 2).
+// All such spaced selections are equally likely: k indices are sampled from a range shrunk by
+// the mandatory gaps, n-(k-1)*(minGap-1), and the i-th (sorted) index is then shifted by i*(minGap-1).
+// A minGap < 1 is treated as 1, meaning just distinct indices.
+// If k <= 0, an empty slice is returned.
+// It returns [ErrInfeasibleGap] if the indices do not fit, that is if (k-1)*minGap >= n.
+func PickSpaced(n, k, minGap int) ([]int, error) {
+	if k <= 0 {
+		return []int{}, nil
+	}
+	if minGap < 1 {
+		minGap = 1
+	}
+	if n <= 0 || (k > 1 && minGap > (n-1)/(k-1)) { // Note: division avoids (k-1)*minGap overflow.
+		return nil, ErrInfeasibleGap
+	}
+
+	shift := minGap - 1
+	indices := SampleIndices(n-(k-1)*shift, k)
+	sort.Ints(indices)
+	for i := range indices {
+		indices[i] += i * shift
+	}
+
+	return indices, nil
+}
+
+// Partition splits total into parts random positive integers, summing exactly to total.
+// Each of the C(total-1, parts-1) possible compositions is equally likely to be returned,
+// as it is chosen with the "stars and bars" method: parts-1 distinct cut points are sampled
+// from the total-1 gaps between total units.
+// It is useful to spread a total load over workers / time slots.
+// It panics if total <= 0, parts <= 0 or parts > total.
+func Partition(total, parts int) []int {
+	if total <= 0 || parts <= 0 || parts > total {
+		panic("invalid argument to Partition")
+	}
+
+	cuts := SampleIndices(total-1, parts-1)
+	sort.Ints(cuts)
+
+	summands := make([]int, parts)
+	prev := 0
+	for i, cut := range cuts {
+		summands[i] = cut + 1 - prev // cut point at index i lies after the (i+1)th unit.
+		prev = cut + 1
+	}
+	summands[parts-1] = total - prev
+
+	return summands
+}
+
+// Draw returns a random element from items, and items without that element.
+// It is efficient, as the drawn element is swapped with the last one and the slice is shortened,
+// thus the order of items is not preserved, and the input's underlying array is modified.
+// It is suitable for "deal without replacement" loops:
+//
+//	for len(cards) > 0 {
+//		card, cards = xrand.Draw(cards)
+//	}
+//
+// It panics if items is empty.
+func Draw[T any](items []T) (T, []T) {
+	var (
+		idx  = globalRand.Intn(len(items))
+		last = len(items) - 1
+		item = items[idx]
+	)
+	items[idx], items[last] = items[last], items[idx]
+
+	return item, items[:last]
+}
+
+// Riffle returns a random interleaving of a and b, preserving each input's relative order,
+// like a card riffle shuffle. At each step, the next element is taken from a or b with a probability
+// proportional to their remaining no. of elements, so all interleavings are equally likely.
+// Inputs are not modified.
+func Riffle[T any](a, b []T) []T {
+	var (
+		result = make([]T, 0, len(a)+len(b))
+		i, j   int
+	)
+	for i < len(a) && j < len(b) {
+		remainingA, remainingB := len(a)-i, len(b)-j
+		if globalRand.Intn(remainingA+remainingB) < remainingA {
+			result = append(result, a[i])
+			i++
+		} else {
+			result = append(result, b[j])
+			j++
+		}
+	}
+	result = append(result, a[i:]...)
+
+	return append(result, b[j:]...)
+}
+
+// Shuffled returns a shuffled copy of items, using the Fisher-Yates algorithm,
+// for callers which cannot shuffle in place, as items are shared.
+// Items are not modified. A nil slice is returned for nil items.
+func Shuffled[T any](items []T) []T {
+	if items == nil {
+		return nil
+	}
+
+	shuffled := make([]T, len(items))
+	for i := range shuffled { // Note: "inside-out" Fisher-Yates, shuffles while copying.
+		j := globalRand.Intn(i + 1)
+		shuffled[i] = shuffled[j]
+		shuffled[j] = items[i]
+	}
+
+	return shuffled
+}
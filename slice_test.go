@@ -0,0 +1,636 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/actforgood/xrand"
+)
+
+func TestSampleIndices(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject = xrand.SampleIndices
+		tests   = [...]struct {
+			name          string
+			inputN        int
+			inputK        int
+			expectedCount int
+		}{
+			{name: "k < n", inputN: 100, inputK: 10, expectedCount: 10},
+			{name: "k = 1", inputN: 5, inputK: 1, expectedCount: 1},
+			{name: "k = n", inputN: 20, inputK: 20, expectedCount: 20},
+			{name: "k > n", inputN: 7, inputK: 50, expectedCount: 7},
+			{name: "k = 0", inputN: 7, inputK: 0, expectedCount: 0},
+			{name: "k < 0", inputN: 7, inputK: -2, expectedCount: 0},
+			{name: "n = 0", inputN: 0, inputK: 3, expectedCount: 0},
+		}
+	)
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				// act
+				result := subject(test.inputN, test.inputK)
+
+				// assert
+				assertEqual(t, test.expectedCount, len(result))
+				assertDistinctIndices(t, result, test.inputN)
+			}
+		})
+	}
+
+	t.Run("every index is reachable", func(t *testing.T) {
+		// arrange
+		seen := make(map[int]struct{}, 10)
+
+		// act
+		for i := 0; i < 1000; i++ {
+			for _, idx := range subject(10, 2) {
+				seen[idx] = struct{}{}
+			}
+		}
+
+		// assert
+		assertEqual(t, 10, len(seen))
+	})
+}
+
+func TestUniqueInts(t *testing.T) {
+	t.Parallel()
+
+	t.Run("distinct values in range", testUniqueIntsInRange)
+	t.Run("distribution is flat", testUniqueIntsIsUniform)
+	t.Run("errors", testUniqueIntsErrors)
+}
+
+func testUniqueIntsInRange(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	tests := [...]struct {
+		name string
+		min  int
+		max  int
+		k    int
+	}{
+		{name: "sparse", min: 0, max: 1000000, k: 100},
+		{name: "sparse, huge range", min: math.MinInt, max: math.MaxInt, k: 50},
+		{name: "sparse, half of the range", min: -10, max: 10, k: 10},
+		{name: "dense", min: 100, max: 120, k: 15},
+		{name: "dense, whole range", min: -5, max: 5, k: 10},
+		{name: "k = 0", min: 0, max: 10, k: 0},
+		{name: "k < 0", min: 0, max: 10, k: -1},
+	}
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			for i := 0; i < 100; i++ {
+				// act
+				result, err := xrand.UniqueInts(test.min, test.max, test.k)
+
+				// assert
+				assertNil(t, err)
+				expectedLen := test.k
+				if expectedLen < 0 {
+					expectedLen = 0
+				}
+				assertEqual(t, expectedLen, len(result))
+				seen := make(map[int]struct{}, len(result))
+				for _, v := range result {
+					assertTrue(t, v >= test.min && v < test.max)
+					seen[v] = struct{}{}
+				}
+				assertEqual(t, len(result), len(seen))
+			}
+		})
+	}
+}
+
+func testUniqueIntsIsUniform(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 20000
+	tests := [...]struct {
+		name string
+		k    int
+	}{
+		{name: "sparse", k: 3},
+		{name: "dense", k: 8},
+	}
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			counts := make([]int, 10)
+			for i := 0; i < iterations; i++ {
+				// act
+				result, err := xrand.UniqueInts(10, 20, test.k)
+
+				// assert
+				assertNil(t, err)
+				for _, v := range result {
+					counts[v-10]++
+				}
+			}
+			assertUniform(t, counts, iterations*test.k, 0.05)
+		})
+	}
+}
+
+func testUniqueIntsErrors(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	tests := [...]struct {
+		name        string
+		min         int
+		max         int
+		k           int
+		expectedErr error
+	}{
+		{name: "too many values", min: 0, max: 10, k: 11, expectedErr: xrand.ErrNotEnoughValues},
+		{name: "empty range", min: 5, max: 5, k: 1, expectedErr: xrand.ErrInvalidRange},
+		{name: "inverted range", min: 5, max: 4, k: 1, expectedErr: xrand.ErrInvalidRange},
+	}
+
+	for _, test := range tests {
+		// act
+		result, err := xrand.UniqueInts(test.min, test.max, test.k)
+
+		// assert
+		assertTrue(t, errors.Is(err, test.expectedErr))
+		assertTrue(t, result == nil)
+	}
+}
+
+func TestPickSpaced(t *testing.T) {
+	t.Parallel()
+
+	t.Run("spaced, sorted indices in range", testPickSpacedConstraints)
+	t.Run("every spaced selection is equally likely", testPickSpacedIsUniform)
+	t.Run("error for infeasible requests", testPickSpacedInfeasible)
+}
+
+func testPickSpacedConstraints(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	tests := [...]struct {
+		name   string
+		n      int
+		k      int
+		minGap int
+	}{
+		{name: "not adjacent", n: 20, k: 5, minGap: 2},
+		{name: "large gap", n: 100, k: 4, minGap: 30},
+		{name: "tight fit", n: 13, k: 4, minGap: 4},
+		{name: "gap 1 means distinct", n: 10, k: 10, minGap: 1},
+		{name: "gap < 1 means distinct", n: 10, k: 3, minGap: -2},
+		{name: "single index", n: 5, k: 1, minGap: 100},
+		{name: "k = 0", n: 5, k: 0, minGap: 2},
+	}
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			minGap := test.minGap
+			if minGap < 1 {
+				minGap = 1
+			}
+			for i := 0; i < 200; i++ {
+				// act
+				result, err := xrand.PickSpaced(test.n, test.k, test.minGap)
+
+				// assert
+				assertNil(t, err)
+				assertEqual(t, test.k, len(result))
+				for idx, v := range result {
+					assertTrue(t, v >= 0 && v < test.n)
+					if idx > 0 {
+						assertTrue(t, v-result[idx-1] >= minGap)
+					}
+				}
+			}
+		})
+	}
+}
+
+func testPickSpacedIsUniform(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 60000
+	var (
+		// k = 2 indices from [0,5), at least 2 apart.
+		selections = map[string]int{"[0 2]": 0, "[0 3]": 1, "[0 4]": 2, "[1 3]": 3, "[1 4]": 4, "[2 4]": 5}
+		counts     = make([]int, len(selections))
+	)
+
+	for i := 0; i < iterations; i++ {
+		// act
+		result, err := xrand.PickSpaced(5, 2, 2)
+
+		// assert
+		assertNil(t, err)
+		idx, found := selections[fmt.Sprint(result)]
+		if !assertTrue(t, found) {
+			return
+		}
+		counts[idx]++
+	}
+	assertUniform(t, counts, iterations, 0.05)
+}
+
+func testPickSpacedInfeasible(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	tests := [...]struct {
+		name   string
+		n      int
+		k      int
+		minGap int
+	}{
+		{name: "gap too large", n: 12, k: 4, minGap: 4},
+		{name: "more indices than n", n: 5, k: 6, minGap: 1},
+		{name: "empty range", n: 0, k: 1, minGap: 1},
+		{name: "huge gap", n: 10, k: 3, minGap: math.MaxInt},
+	}
+
+	for _, test := range tests {
+		// act
+		result, err := xrand.PickSpaced(test.n, test.k, test.minGap)
+
+		// assert
+		assertTrue(t, errors.Is(err, xrand.ErrInfeasibleGap))
+		assertTrue(t, result == nil)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sums to total with positive parts", testPartitionSum)
+	t.Run("compositions are uniform", testPartitionIsUniform)
+	t.Run("panics for invalid arguments", testPartitionPanics)
+}
+
+func testPartitionSum(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	tests := [...]struct {
+		total int
+		parts int
+	}{
+		{total: 1, parts: 1},
+		{total: 10, parts: 1},
+		{total: 10, parts: 10},
+		{total: 100, parts: 7},
+		{total: 1000, parts: 999},
+	}
+
+	for _, test := range tests {
+		partitions := make(map[string]struct{})
+		for i := 0; i < 200; i++ {
+			// act
+			result := xrand.Partition(test.total, test.parts)
+
+			// assert
+			assertEqual(t, test.parts, len(result))
+			sum := 0
+			for _, part := range result {
+				assertTrue(t, part >= 1)
+				sum += part
+			}
+			assertEqual(t, test.total, sum)
+			partitions[fmt.Sprint(result)] = struct{}{}
+		}
+		if test.parts == 1 || test.parts == test.total { // only one composition possible
+			assertEqual(t, 1, len(partitions))
+		} else {
+			assertTrue(t, len(partitions) > 1)
+		}
+	}
+}
+
+func testPartitionIsUniform(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 60000
+	var (
+		compositions = map[string]int{"[1 1 3]": 0, "[1 3 1]": 1, "[3 1 1]": 2, "[1 2 2]": 3, "[2 1 2]": 4, "[2 2 1]": 5}
+		counts       = make([]int, len(compositions))
+	)
+
+	for i := 0; i < iterations; i++ {
+		// act
+		result := xrand.Partition(5, 3)
+
+		// assert
+		idx, found := compositions[fmt.Sprint(result)]
+		if assertTrue(t, found) {
+			counts[idx]++
+		}
+	}
+	assertUniform(t, counts, iterations, 0.05)
+}
+
+func testPartitionPanics(t *testing.T) {
+	t.Parallel()
+
+	// act & assert
+	assertPanics(t, func() { _ = xrand.Partition(0, 1) })
+	assertPanics(t, func() { _ = xrand.Partition(-5, 2) })
+	assertPanics(t, func() { _ = xrand.Partition(5, 0) })
+	assertPanics(t, func() { _ = xrand.Partition(5, -1) })
+	assertPanics(t, func() { _ = xrand.Partition(5, 6) })
+}
+
+func TestDraw(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject = xrand.Draw[int]
+		items   = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+		drawn   = make([]int, 0, len(items))
+		item    int
+	)
+
+	for len(items) > 0 {
+		initialLen := len(items)
+
+		// act
+		item, items = subject(items)
+
+		// assert
+		assertEqual(t, initialLen-1, len(items))
+		for _, remaining := range items {
+			assertTrue(t, remaining != item)
+		}
+		drawn = append(drawn, item)
+	}
+	assertSamePermutation(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, drawn)
+
+	t.Run("panics for empty items", func(t *testing.T) {
+		assertPanics(t, func() {
+			_, _ = subject(items)
+		})
+	})
+}
+
+func TestRiffle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("relative order is preserved", testRiffleRelativeOrder)
+	t.Run("interleavings are uniform", testRiffleIsUniform)
+	t.Run("empty inputs", testRiffleEmptyInputs)
+}
+
+func testRiffleRelativeOrder(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject      = xrand.Riffle[int]
+		a            = []int{1, 2, 3, 4, 5, 6, 7}
+		b            = []int{-1, -2, -3, -4}
+		interleaving = make(map[string]struct{})
+	)
+
+	for i := 0; i < 100; i++ {
+		// act
+		result := subject(a, b)
+
+		// assert
+		assertEqual(t, len(a)+len(b), len(result))
+		var fromA, fromB []int
+		for _, item := range result {
+			if item > 0 {
+				fromA = append(fromA, item)
+			} else {
+				fromB = append(fromB, item)
+			}
+		}
+		assertTrue(t, reflect.DeepEqual(a, fromA))
+		assertTrue(t, reflect.DeepEqual(b, fromB))
+		interleaving[fmt.Sprint(result)] = struct{}{}
+	}
+	assertTrue(t, len(interleaving) > 1)
+	assertTrue(t, reflect.DeepEqual([]int{1, 2, 3, 4, 5, 6, 7}, a)) // inputs are not modified
+	assertTrue(t, reflect.DeepEqual([]int{-1, -2, -3, -4}, b))
+}
+
+func testRiffleIsUniform(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 60000
+	var (
+		subject       = xrand.Riffle[string]
+		interleavings = map[string]int{"abXY": 0, "aXbY": 1, "aXYb": 2, "XabY": 3, "XaYb": 4, "XYab": 5}
+		counts        = make([]int, len(interleavings))
+	)
+
+	for i := 0; i < iterations; i++ {
+		// act
+		result := subject([]string{"a", "b"}, []string{"X", "Y"})
+
+		// assert
+		idx, found := interleavings[strings.Join(result, "")]
+		if assertTrue(t, found) {
+			counts[idx]++
+		}
+	}
+	assertUniform(t, counts, iterations, 0.05)
+}
+
+func testRiffleEmptyInputs(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	subject := xrand.Riffle[int]
+
+	// act & assert
+	assertTrue(t, reflect.DeepEqual([]int{1, 2}, subject([]int{1, 2}, nil)))
+	assertTrue(t, reflect.DeepEqual([]int{1, 2}, subject(nil, []int{1, 2})))
+	assertEqual(t, 0, len(subject(nil, nil)))
+}
+
+// assertDistinctIndices checks that indices are distinct and in range [0,n).
+// Returns successful assertion status.
+func assertDistinctIndices(t *testing.T, indices []int, n int) bool {
+	t.Helper()
+	seen := make(map[int]struct{}, len(indices))
+	for _, idx := range indices {
+		if idx < 0 || idx >= n {
+			t.Errorf("index %d is out of range [0,%d)", idx, n)
+
+			return false
+		}
+		if _, found := seen[idx]; found {
+			t.Errorf("index %d is duplicated", idx)
+
+			return false
+		}
+		seen[idx] = struct{}{}
+	}
+
+	return true
+}
+
+func BenchmarkSampleIndices(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = xrand.SampleIndices(100, 10)
+	}
+}
+
+func TestShuffled(t *testing.T) {
+	t.Parallel()
+
+	t.Run("copy is a permutation, input is unmodified", testShuffledIsPermutation)
+	t.Run("every permutation is equally likely", testShuffledIsUniform)
+	t.Run("empty items", testShuffledEmpty)
+}
+
+func testShuffledIsPermutation(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		items    = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+		original = append([]int(nil), items...)
+		orders   = make(map[string]struct{})
+	)
+
+	for i := 0; i < 100; i++ {
+		// act
+		result := xrand.Shuffled(items)
+
+		// assert
+		assertEqual(t, len(items), len(result))
+		assertSamePermutation(t, items, result)
+		assertTrue(t, reflect.DeepEqual(original, items))
+		orders[fmt.Sprint(result)] = struct{}{}
+	}
+	assertTrue(t, len(orders) > 90)
+}
+
+func testShuffledIsUniform(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 60000
+	var (
+		items        = []string{"a", "b", "c"}
+		permutations = map[string]int{"abc": 0, "acb": 1, "bac": 2, "bca": 3, "cab": 4, "cba": 5}
+		counts       = make([]int, len(permutations))
+	)
+
+	for i := 0; i < iterations; i++ {
+		// act
+		result := xrand.Shuffled(items)
+
+		// assert
+		counts[permutations[strings.Join(result, "")]]++
+	}
+	assertUniform(t, counts, iterations, 0.05)
+}
+
+func testShuffledEmpty(t *testing.T) {
+	t.Parallel()
+
+	// act & assert
+	assertTrue(t, xrand.Shuffled([]int(nil)) == nil)
+	result := xrand.Shuffled([]int{})
+	assertTrue(t, result != nil)
+	assertEqual(t, 0, len(result))
+}
+
+func ExampleSampleIndices() {
+	// sample the same 2 random positions across parallel slices.
+	names := []string{"Alice", "Bob", "Carol", "Dave"}
+	ages := []int{30, 25, 35, 40}
+	for _, idx := range xrand.SampleIndices(len(names), 2) {
+		fmt.Println(names[idx], ages[idx])
+	}
+}
+
+func ExampleUniqueInts() {
+	// generate 5 distinct test user ids.
+	ids, err := xrand.UniqueInts(1000, 10000, 5)
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+	fmt.Println(ids)
+}
+
+func ExamplePickSpaced() {
+	// place 3 ads among 10 feed positions, never in adjacent positions.
+	slots, err := xrand.PickSpaced(10, 3, 2)
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+	fmt.Println(slots) // like [1 5 7]
+}
+
+func ExamplePartition() {
+	// spread 1000 requests over 5 workers.
+	loads := xrand.Partition(1000, 5)
+	for worker, load := range loads {
+		fmt.Printf("worker %d sends %d requests\n", worker, load)
+	}
+}
+
+func ExampleDraw() {
+	// deal all the cards, without replacement.
+	cards := []string{"A", "K", "Q", "J"}
+	var card string
+	for len(cards) > 0 {
+		card, cards = xrand.Draw(cards)
+		fmt.Println(card)
+	}
+}
+
+func ExampleRiffle() {
+	// randomly interleave 2 event streams, preserving each stream's order.
+	merged := xrand.Riffle([]string{"a1", "a2", "a3"}, []string{"b1", "b2"})
+	fmt.Println(merged)
+}
+
+func ExampleShuffled() {
+	// shuffle a shared, read-only, list of servers.
+	servers := []string{"server1", "server2", "server3"}
+	shuffled := xrand.Shuffled(servers)
+	fmt.Println(shuffled, servers) // like [server3 server1 server2] [server1 server2 server3]
+}
@@ -0,0 +1,78 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand
+
+import "strings"
+
+// slugAdjectives are the embedded adjectives [Slug] picks from.
+var slugAdjectives = [...]string{
+	"agile", "ancient", "bold", "brave", "bright", "calm", "clever", "cosmic",
+	"crimson", "curious", "daring", "dusty", "eager", "electric", "fancy", "fierce",
+	"gentle", "golden", "grand", "happy", "hidden", "humble", "icy", "jolly",
+	"kind", "lively", "lucky", "mellow", "misty", "noble", "odd", "proud",
+	"purple", "quick", "quiet", "rapid", "rusty", "shiny", "silent", "silver",
+	"sleepy", "snowy", "solar", "swift", "tiny", "vivid", "wild", "witty",
+}
+
+// slugNouns are the embedded nouns [Slug] picks from.
+var slugNouns = [...]string{
+	"badger", "beacon", "breeze", "canyon", "comet", "coral", "crane", "delta",
+	"dolphin", "eagle", "ember", "falcon", "forest", "fox", "glacier", "harbor",
+	"hawk", "heron", "island", "lagoon", "lantern", "lynx", "meadow", "meteor",
+	"moon", "nebula", "ocean", "orbit", "otter", "owl", "panda", "pebble",
+	"phoenix", "pine", "planet", "prairie", "raven", "reef", "river", "rocket",
+	"sparrow", "summit", "thunder", "tiger", "valley", "walrus", "willow", "wolf",
+}
+
+// defaultSlugGenerator generates slugs from the embedded word lists.
+var defaultSlugGenerator = NewSlugGenerator(slugAdjectives[:], slugNouns[:])
+
+// Slug generates a random human-friendly identifier made of parts words joined by sep,
+// like "brave-purple-otter" for 3 parts and sep "-".
+// Words are picked from small embedded lists: a noun ends the slug, preceded by adjectives.
+// Use a [SlugGenerator] for custom word lists.
+// An empty string is returned if parts <= 0.
+func Slug(parts int, sep string) string {
+	return defaultSlugGenerator.Generate(parts, sep)
+}
+
+// SlugGenerator generates random slugs, like [Slug], but from custom word lists.
+// It is safe for concurrent use by multiple goroutines.
+type SlugGenerator struct {
+	adjectives []string
+	nouns      []string
+}
+
+// NewSlugGenerator instantiates a new SlugGenerator, which generates slugs
+// from given adjectives and nouns. Word lists are copied.
+// It panics if any of the word lists is empty.
+func NewSlugGenerator(adjectives, nouns []string) *SlugGenerator {
+	if len(adjectives) == 0 || len(nouns) == 0 {
+		panic("invalid argument to NewSlugGenerator")
+	}
+
+	return &SlugGenerator{
+		adjectives: append([]string(nil), adjectives...),
+		nouns:      append([]string(nil), nouns...),
+	}
+}
+
+// Generate returns a new random slug made of parts words joined by sep:
+// parts-1 adjectives, followed by a noun.
+// An empty string is returned if parts <= 0.
+func (gen *SlugGenerator) Generate(parts int, sep string) string {
+	if parts <= 0 {
+		return ""
+	}
+
+	words := make([]string, parts)
+	for i := 0; i < parts-1; i++ {
+		words[i] = gen.adjectives[globalRand.Intn(len(gen.adjectives))]
+	}
+	words[parts-1] = gen.nouns[globalRand.Intn(len(gen.nouns))]
+
+	return strings.Join(words, sep)
+}
@@ -0,0 +1,137 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/actforgood/xrand"
+)
+
+func TestSlug(t *testing.T) {
+	t.Parallel()
+
+	t.Run("parts are embedded words", testSlugParts)
+	t.Run("slugs vary", testSlugVary)
+	t.Run("non-positive parts", testSlugNonPositiveParts)
+}
+
+func testSlugParts(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		adjectives, nouns = xrand.SlugWords()
+		isAdjective       = toSet(adjectives)
+		isNoun            = toSet(nouns)
+	)
+
+	for _, sep := range [...]string{"-", "_", "::"} {
+		for parts := 1; parts <= 5; parts++ {
+			// act
+			result := xrand.Slug(parts, sep)
+
+			// assert
+			words := strings.Split(result, sep)
+			assertEqual(t, parts, len(words))
+			for idx, word := range words {
+				if idx == parts-1 {
+					assertTrue(t, isNoun[word])
+				} else {
+					assertTrue(t, isAdjective[word])
+				}
+			}
+		}
+	}
+}
+
+func testSlugVary(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	seen := make(map[string]struct{}, 100)
+
+	for i := 0; i < 100; i++ {
+		// act
+		result := xrand.Slug(3, "-")
+
+		// assert
+		seen[result] = struct{}{}
+	}
+	assertTrue(t, len(seen) > 90)
+}
+
+func testSlugNonPositiveParts(t *testing.T) {
+	t.Parallel()
+
+	// act & assert
+	assertEqual(t, "", xrand.Slug(0, "-"))
+	assertEqual(t, "", xrand.Slug(-1, "-"))
+}
+
+func TestSlugGenerator(t *testing.T) {
+	t.Parallel()
+
+	t.Run("parts are custom words", testSlugGeneratorCustomWords)
+	t.Run("panics for empty word lists", testSlugGeneratorPanics)
+}
+
+func testSlugGeneratorCustomWords(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		adjectives = []string{"red", "blue"}
+		nouns      = []string{"cat"}
+		subject    = xrand.NewSlugGenerator(adjectives, nouns)
+		seen       = make(map[string]struct{}, 4)
+	)
+	adjectives[0] = "green" // word lists are copied.
+
+	for i := 0; i < 200; i++ {
+		// act
+		result := subject.Generate(3, ".")
+
+		// assert
+		seen[result] = struct{}{}
+	}
+	assertEqual(t, 4, len(seen))
+	for _, expected := range [...]string{"red.red.cat", "red.blue.cat", "blue.red.cat", "blue.blue.cat"} {
+		_, found := seen[expected]
+		assertTrue(t, found)
+	}
+}
+
+func testSlugGeneratorPanics(t *testing.T) {
+	t.Parallel()
+
+	// act & assert
+	assertPanics(t, func() { _ = xrand.NewSlugGenerator(nil, []string{"cat"}) })
+	assertPanics(t, func() { _ = xrand.NewSlugGenerator([]string{"red"}, []string{}) })
+}
+
+// toSet returns a set of given words.
+func toSet(words []string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+
+	return set
+}
+
+func ExampleSlug() {
+	// generate a human-friendly deployment name.
+	fmt.Println(xrand.Slug(3, "-")) // like brave-purple-otter
+}
+
+func ExampleSlugGenerator() {
+	// generate slugs from custom word lists.
+	generator := xrand.NewSlugGenerator([]string{"red", "blue", "green"}, []string{"team", "squad"})
+	fmt.Println(generator.Generate(2, "_")) // like blue_squad
+}
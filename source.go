@@ -0,0 +1,104 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+// ErrInvalidState is returned when unmarshalling a random generator's state fails.
+var ErrInvalidState = errors.New("xrand: invalid random generator state")
+
+// pcgStatePrefix prefixes a PCG source's marshalled state.
+const pcgStatePrefix = "pcg:"
+
+// pcgSource is a PCG (permuted congruential generator) source of random numbers,
+// with 128 bits of state and a DXSM (double xorshift multiply) output function.
+// Unlike math/rand's sources, its state is exported easily, which makes it possible
+// to snapshot / restore it.
+// The code is very similar to math/rand/v2.PCG, which is unfortunately not available for go < 1.22.
+// It is not safe for concurrent use by multiple goroutines.
+type pcgSource struct {
+	hi uint64
+	lo uint64
+}
+
+// newPCGSource returns a new PCG source, seeded with given seed.
+func newPCGSource(seed int64) *pcgSource {
+	src := new(pcgSource)
+	src.Seed(seed)
+
+	return src
+}
+
+// Seed...
+func (src *pcgSource) Seed(seed int64) {
+	src.hi = uint64(seed)
+	src.lo = uint64(seed) ^ 0x9e3779b97f4a7c15 // golden ratio, decorrelates the 2 halves.
+}
+
+// Int63...
+func (src *pcgSource) Int63() int64 {
+	return int64(src.Uint64() >> 1)
+}
+
+// Uint64...
+func (src *pcgSource) Uint64() uint64 {
+	hi, lo := src.next()
+
+	// DXSM "double xorshift multiply"
+	// https://github.com/imneme/pcg-cpp/blob/428802d1a5/include/pcg_random.hpp#L1015
+	const cheapMul = 0xda942042e4dd58b5
+	hi ^= hi >> 32
+	hi *= cheapMul
+	hi ^= hi >> (3 * 16)
+	hi *= (lo | 1)
+
+	return hi
+}
+
+// next advances the 128 bits LCG state: state = state * mul + inc, and returns the new state.
+func (src *pcgSource) next() (hi, lo uint64) {
+	const (
+		mulHi = 2549297995355413924
+		mulLo = 4865540595714422341
+		incHi = 6364136223846793005
+		incLo = 1442695040888963407
+	)
+
+	hi, lo = bits.Mul64(src.lo, mulLo)
+	hi += src.hi*mulLo + src.lo*mulHi
+	lo, c := bits.Add64(lo, incLo, 0)
+	hi, _ = bits.Add64(hi, incHi, c)
+	src.lo = lo
+	src.hi = hi
+
+	return hi, lo
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (src *pcgSource) MarshalBinary() ([]byte, error) {
+	b := make([]byte, len(pcgStatePrefix)+16)
+	copy(b, pcgStatePrefix)
+	binary.BigEndian.PutUint64(b[len(pcgStatePrefix):], src.hi)
+	binary.BigEndian.PutUint64(b[len(pcgStatePrefix)+8:], src.lo)
+
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (src *pcgSource) UnmarshalBinary(data []byte) error {
+	if len(data) != len(pcgStatePrefix)+16 || string(data[:len(pcgStatePrefix)]) != pcgStatePrefix {
+		return ErrInvalidState
+	}
+	data = data[len(pcgStatePrefix):]
+	src.hi = binary.BigEndian.Uint64(data)
+	src.lo = binary.BigEndian.Uint64(data[8:])
+
+	return nil
+}
@@ -0,0 +1,288 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// RandomCase returns s with each letter independently upper or lower cased at random.
+// Non-letters (and invalid UTF-8 bytes) are left unchanged.
+// It is useful for fuzzing case-insensitive logic.
+func RandomCase(s string) string {
+	var (
+		sb          strings.Builder
+		randomInt63 int64
+		remaining   int // no. of random bits left in randomInt63
+	)
+	sb.Grow(len(s))
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 { // invalid UTF-8 byte, preserve it
+			sb.WriteByte(s[i])
+			i++
+
+			continue
+		}
+		if unicode.IsLetter(r) {
+			if remaining == 0 {
+				randomInt63, remaining = globalRand.Int63(), 63
+			}
+			if randomInt63&1 == 1 {
+				r = unicode.ToUpper(r)
+			} else {
+				r = unicode.ToLower(r)
+			}
+			randomInt63 >>= 1
+			remaining--
+		}
+		sb.WriteRune(r)
+		i += size
+	}
+
+	return sb.String()
+}
+
+// ErrInsufficientAlphabet is returned when an alphabet cannot provide the requested entropy,
+// as it has less than 2 distinct characters.
+var ErrInsufficientAlphabet = errors.New("xrand: alphabet must have at least 2 distinct characters")
+
+// StringEntropyBits returns the entropy, in bits, of a string of length n
+// generated with [String] from the given alphabet.
+// Alphabet defaults to [AlphanumAlphabet] if empty, as with [String].
+// For an alphabet of distinct characters, the result is n * log2(len(alphabet)).
+// Duplicate characters are accounted for: as [String] picks each alphabet position with
+// equal probability, a duplicated character is more likely to be picked, and the
+// entropy per character is the Shannon entropy of the characters' frequencies,
+// which is lower than log2 of the number of distinct characters.
+func StringEntropyBits(n int, alphabet string) float64 {
+	return float64(n) * alphabetEntropyBits(alphabet)
+}
+
+// StringRejectionRate returns the expected fraction of random draws [String] wastes for the given alphabet,
+// to diagnose slow alphabets. Each letter is drawn from the minimum no. of random bits covering
+// the alphabet's length, and draws falling outside of it are rejected, thus the rate is 1 - len/2^bits.
+// It is 0 for alphabets whose length is a power of two, and the worst (close to 0.5) for lengths
+// just above a power of two, like 33, for which trimming a letter (or adding up to 64) is much faster.
+// Alphabet defaults to [AlphanumAlphabet] if empty, as with [String].
+func StringRejectionRate(alphabet string) float64 {
+	if alphabet == "" {
+		alphabet = AlphanumAlphabet
+	}
+
+	return 1 - float64(len(alphabet))/float64(uint64(1)<<countBits(len(alphabet)))
+}
+
+// StringMinEntropy generates a random string with letters from the alphabet,
+// long enough to have at least minBits of entropy (see [StringEntropyBits]).
+// Alphabet defaults to [AlphanumAlphabet] if empty, as with [String].
+// It returns [ErrInsufficientAlphabet] if alphabet has less than 2 distinct characters.
+func StringMinEntropy(minBits float64, alphabet string) (string, error) {
+	bitsPerChar := alphabetEntropyBits(alphabet)
+	if bitsPerChar <= 0 {
+		return "", ErrInsufficientAlphabet
+	}
+	if minBits <= 0 {
+		return "", nil
+	}
+
+	n := int(math.Ceil(minBits / bitsPerChar))
+	for float64(n)*bitsPerChar < minBits { // guard against float rounding
+		n++
+	}
+
+	return String(n, alphabet), nil
+}
+
+// alphabetEntropyBits returns the Shannon entropy, in bits, of a character
+// uniformly picked from alphabet's positions.
+func alphabetEntropyBits(alphabet string) float64 {
+	if len(alphabet) == 0 {
+		alphabet = AlphanumAlphabet
+	}
+
+	var counts [256]int
+	for i := 0; i < len(alphabet); i++ {
+		counts[alphabet[i]]++
+	}
+
+	var (
+		entropy float64
+		total   = float64(len(alphabet))
+	)
+	for _, count := range counts {
+		if count > 0 {
+			p := float64(count) / total
+			entropy -= p * math.Log2(p)
+		}
+	}
+
+	return entropy
+}
+
+// ErrNotEnoughDistinctChars is returned when more distinct characters are requested than an alphabet has.
+var ErrNotEnoughDistinctChars = errors.New("xrand: alphabet has fewer distinct characters than requested")
+
+// DistinctChars generates a random string of n distinct characters from the alphabet:
+// unlike [String], no character is repeated.
+// It is useful for short codes, where repeated characters are undesired.
+// Duplicate characters in alphabet are counted once, and all distinct characters are equally likely.
+// Alphabet defaults to [AlphanumAlphabet] if empty, as with [String].
+// It returns [ErrNotEnoughDistinctChars] if n is greater than the no. of distinct characters in alphabet.
+func DistinctChars(n int, alphabet string) (string, error) {
+	if len(alphabet) == 0 {
+		alphabet = AlphanumAlphabet
+	}
+	if n <= 0 {
+		return "", nil
+	}
+
+	var (
+		seen  [256]bool
+		chars = make([]byte, 0, len(alphabet))
+	)
+	for i := 0; i < len(alphabet); i++ {
+		if !seen[alphabet[i]] {
+			seen[alphabet[i]] = true
+			chars = append(chars, alphabet[i])
+		}
+	}
+	if n > len(chars) {
+		return "", ErrNotEnoughDistinctChars
+	}
+
+	// partial Fisher-Yates: only the first n positions get shuffled.
+	for i := 0; i < n; i++ {
+		j := i + globalRand.Intn(len(chars)-i)
+		chars[i], chars[j] = chars[j], chars[i]
+	}
+	chars = chars[:n]
+
+	return *(*string)(unsafe.Pointer(&chars)), nil
+}
+
+// ReadableString generates a random string of length n with letters from
+// [Base32CrockfordAlphabet], which excludes visually ambiguous letters (I, L, O, U),
+// making it suitable for codes humans read aloud, like account recovery codes.
+// Alphabet has 32 symbols, so each letter maps to exactly 5 random bits and no draw is ever rejected.
+func ReadableString(n int) string {
+	return String(n, Base32CrockfordAlphabet)
+}
+
+// PrefixedString generates a random string of length n with letters from the alphabet,
+// prefixed with given prefix, like "usr_a1b2c3d4". Returned string has len(prefix)+n bytes.
+// Alphabet is optional and defaults to [AlphanumAlphabet] if not provided.
+func PrefixedString(prefix string, n int, alphabet ...string) string {
+	return NewPrefixedGenerator(prefix, n, alphabet...).Generate()
+}
+
+// StringPadded generates a random string of length n with letters from the alphabet,
+// right-padded with fill up to width bytes, like "a1b2c___" for n 5, width 8 and fill '_'.
+// If n >= width, the random string is returned unpadded, thus its length is max(n, width).
+// Alphabet is optional and defaults to [AlphanumAlphabet] if not provided.
+// See [StringPaddedLeft] for the left-padded variant.
+func StringPadded(n, width int, fill byte, alphabet ...string) string {
+	return paddedString(n, width, fill, false, alphabet)
+}
+
+// StringPaddedLeft is like [StringPadded], but it left-pads the random string with fill,
+// like "___a1b2c" for n 5, width 8 and fill '_'.
+func StringPaddedLeft(n, width int, fill byte, alphabet ...string) string {
+	return paddedString(n, width, fill, true, alphabet)
+}
+
+// paddedString generates a random string of length n, padded with fill up to width bytes,
+// either on the left, or on the right.
+func paddedString(n, width int, fill byte, left bool, alphabet []string) string {
+	size := n
+	if width > n {
+		size = width
+	}
+
+	var (
+		b      = make([]byte, size)
+		random = b[:n]
+		pad    = b[n:]
+	)
+	if left {
+		pad, random = b[:size-n], b[size-n:]
+	}
+	for i := range pad {
+		pad[i] = fill
+	}
+	fillString(random, alphabetOrDefault(alphabet))
+
+	return *(*string)(unsafe.Pointer(&b))
+}
+
+// PrefixedGenerator generates random strings with a fixed prefix, like namespaced IDs ("usr_a1b2c3d4").
+// It is safe for concurrent use by multiple goroutines.
+type PrefixedGenerator struct {
+	prefix   string
+	n        int
+	alphabet string
+}
+
+// NewPrefixedGenerator instantiates a new PrefixedGenerator, which generates strings consisting of prefix,
+// followed by n random letters from the alphabet.
+// Alphabet is optional and defaults to [AlphanumAlphabet] if not provided.
+func NewPrefixedGenerator(prefix string, n int, alphabet ...string) *PrefixedGenerator {
+	return &PrefixedGenerator{
+		prefix:   prefix,
+		n:        n,
+		alphabet: alphabetOrDefault(alphabet),
+	}
+}
+
+// Generate returns a new random prefixed string, of len(prefix)+n bytes.
+// It allocates only once, being suitable for hot loops.
+func (gen *PrefixedGenerator) Generate() string {
+	b := make([]byte, len(gen.prefix)+gen.n)
+	copy(b, gen.prefix)
+	fillString(b[len(gen.prefix):], gen.alphabet)
+
+	return *(*string)(unsafe.Pointer(&b))
+}
+
+// SegmentedID generates a random ID made of segments separated by sep, where segments
+// holds the length of each segment, like "7G4K-Q2ZD-89XA" for segments [4, 4, 4] and sep "-".
+// Segments can have different lengths, for example [8, 4, 4, 12] mimics an UUID's layout.
+// Alphabet is optional and defaults to [AlphanumAlphabet] if not provided.
+// An empty string is returned if segments is empty.
+// It panics if a segment's length is negative.
+func SegmentedID(segments []int, sep string, alphabet ...string) string {
+	if len(segments) == 0 {
+		return ""
+	}
+
+	size := len(sep) * (len(segments) - 1)
+	for _, segmentLen := range segments {
+		if segmentLen < 0 {
+			panic("invalid argument to SegmentedID")
+		}
+		size += segmentLen
+	}
+
+	var (
+		a   = alphabetOrDefault(alphabet)
+		b   = make([]byte, size)
+		pos = 0
+	)
+	for idx, segmentLen := range segments {
+		if idx > 0 {
+			pos += copy(b[pos:], sep)
+		}
+		fillString(b[pos:pos+segmentLen], a)
+		pos += segmentLen
+	}
+
+	return *(*string)(unsafe.Pointer(&b))
+}
@@ -0,0 +1,659 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/actforgood/xrand"
+)
+
+func TestRandomCase(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject = xrand.RandomCase
+		tests   = [...]string{
+			"",
+			"hello world",
+			"Hello, World! 123",
+			"ünïcödé ΣΑΣ straße",
+			"日本語 - no cases",
+			"invalid \xff utf-8",
+		}
+	)
+
+	for _, testData := range tests {
+		input := testData // capture range variable
+		t.Run(input, func(t *testing.T) {
+			var (
+				inputRunes = []rune(input)
+				seenUpper  = make([]bool, len(inputRunes))
+				seenLower  = make([]bool, len(inputRunes))
+			)
+			for i := 0; i < 200; i++ {
+				// act
+				result := subject(input)
+
+				// assert
+				assertTrue(t, strings.EqualFold(input, result))
+				resultRunes := []rune(result)
+				if !assertEqual(t, len(inputRunes), len(resultRunes)) {
+					return
+				}
+				for idx, r := range inputRunes {
+					if !unicode.IsLetter(r) {
+						assertEqual(t, r, resultRunes[idx])
+
+						continue
+					}
+					if unicode.IsUpper(resultRunes[idx]) {
+						seenUpper[idx] = true
+					} else if unicode.IsLower(resultRunes[idx]) {
+						seenLower[idx] = true
+					}
+				}
+			}
+			for idx, r := range inputRunes {
+				if unicode.IsLetter(r) && unicode.ToUpper(r) != unicode.ToLower(r) {
+					assertTrue(t, seenUpper[idx])
+					assertTrue(t, seenLower[idx])
+				}
+			}
+		})
+	}
+}
+
+func TestStringEntropyBits(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject = xrand.StringEntropyBits
+		tests   = [...]struct {
+			name          string
+			inputLength   int
+			inputAlphabet string
+			expectedBits  float64
+		}{
+			{
+				name:          "binary alphabet",
+				inputLength:   128,
+				inputAlphabet: "01",
+				expectedBits:  128,
+			},
+			{
+				name:          "hex alphabet",
+				inputLength:   32,
+				inputAlphabet: "0123456789abcdef",
+				expectedBits:  128,
+			},
+			{
+				name:          "digits alphabet",
+				inputLength:   10,
+				inputAlphabet: xrand.DigitsAlphabet,
+				expectedBits:  10 * math.Log2(10),
+			},
+			{
+				name:          "alphanum alphabet",
+				inputLength:   16,
+				inputAlphabet: xrand.AlphanumAlphabet,
+				expectedBits:  16 * math.Log2(36),
+			},
+			{
+				name:          "empty alphabet - default alphabet",
+				inputLength:   16,
+				inputAlphabet: "",
+				expectedBits:  16 * math.Log2(36),
+			},
+			{
+				name:          "duplicated characters",
+				inputLength:   10,
+				inputAlphabet: "aab", // p(a) = 2/3, p(b) = 1/3
+				expectedBits:  10 * -(2.0/3*math.Log2(2.0/3) + 1.0/3*math.Log2(1.0/3)),
+			},
+			{
+				name:          "fully duplicated characters",
+				inputLength:   8,
+				inputAlphabet: "aabb",
+				expectedBits:  8,
+			},
+			{
+				name:          "single character",
+				inputLength:   100,
+				inputAlphabet: "aaaa",
+				expectedBits:  0,
+			},
+			{
+				name:          "len = 0",
+				inputLength:   0,
+				inputAlphabet: xrand.AlphanumAlphabet,
+				expectedBits:  0,
+			},
+		}
+	)
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			// act
+			result := subject(test.inputLength, test.inputAlphabet)
+
+			// assert
+			assertTrue(t, math.Abs(test.expectedBits-result) < 1e-9)
+		})
+	}
+}
+
+func TestStringRejectionRate(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	tests := [...]struct {
+		name          string
+		inputAlphabet string
+		expectedRate  float64
+	}{
+		{name: "length 16, power of two", inputAlphabet: "0123456789abcdef", expectedRate: 0},
+		{name: "length 17, just above a power of two", inputAlphabet: "0123456789abcdefg", expectedRate: 15.0 / 32},
+		{name: "length 36", inputAlphabet: xrand.AlphanumAlphabet, expectedRate: 28.0 / 64},
+		{name: "length 10", inputAlphabet: xrand.DigitsAlphabet, expectedRate: 6.0 / 16},
+		{name: "single letter", inputAlphabet: "a", expectedRate: 0},
+		{name: "empty, defaults to alphanum", inputAlphabet: "", expectedRate: 28.0 / 64},
+	}
+
+	for _, test := range tests {
+		// act
+		result := xrand.StringRejectionRate(test.inputAlphabet)
+
+		// assert
+		assertEqual(t, test.expectedRate, result)
+	}
+}
+
+func TestStringMinEntropy(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject = xrand.StringMinEntropy
+		tests   = [...]struct {
+			name           string
+			inputMinBits   float64
+			inputAlphabet  string
+			expectedLength int
+		}{
+			{
+				name:           "128 bits, hex alphabet",
+				inputMinBits:   128,
+				inputAlphabet:  "0123456789abcdef",
+				expectedLength: 32,
+			},
+			{
+				name:           "128 bits, alphanum alphabet",
+				inputMinBits:   128,
+				inputAlphabet:  xrand.AlphanumAlphabet,
+				expectedLength: 25, // 24.76 chars
+			},
+			{
+				name:           "64 bits, digits alphabet",
+				inputMinBits:   64,
+				inputAlphabet:  xrand.DigitsAlphabet,
+				expectedLength: 20, // 19.27 chars
+			},
+			{
+				name:           "10 bits, duplicated characters",
+				inputMinBits:   10,
+				inputAlphabet:  "aab",
+				expectedLength: 11, // 10.88 chars
+			},
+			{
+				name:           "0 bits",
+				inputMinBits:   0,
+				inputAlphabet:  xrand.AlphanumAlphabet,
+				expectedLength: 0,
+			},
+		}
+	)
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			// act
+			result, err := subject(test.inputMinBits, test.inputAlphabet)
+
+			// assert
+			assertNil(t, err)
+			assertEqual(t, test.expectedLength, len(result))
+			assertTrue(t, xrand.StringEntropyBits(len(result), test.inputAlphabet) >= test.inputMinBits)
+			for _, char := range result {
+				assertTrue(t, strings.ContainsRune(test.inputAlphabet, char))
+			}
+		})
+	}
+
+	t.Run("insufficient alphabet", func(t *testing.T) {
+		// act
+		result, err := subject(10, "zzz")
+
+		// assert
+		assertTrue(t, errors.Is(err, xrand.ErrInsufficientAlphabet))
+		assertEqual(t, "", result)
+	})
+}
+
+func TestDistinctChars(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject = xrand.DistinctChars
+		tests   = [...]struct {
+			name          string
+			inputLength   int
+			inputAlphabet string
+		}{
+			{
+				name:          "some chars, alphanum alphabet",
+				inputLength:   8,
+				inputAlphabet: xrand.AlphanumAlphabet,
+			},
+			{
+				name:          "all chars, digits alphabet",
+				inputLength:   10,
+				inputAlphabet: xrand.DigitsAlphabet,
+			},
+			{
+				name:          "duplicated characters",
+				inputLength:   3,
+				inputAlphabet: "aabbbc",
+			},
+			{
+				name:          "empty alphabet - default alphabet",
+				inputLength:   36,
+				inputAlphabet: "",
+			},
+			{
+				name:          "0 chars",
+				inputLength:   0,
+				inputAlphabet: "abc",
+			},
+		}
+	)
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			alphabet := test.inputAlphabet
+			if alphabet == "" {
+				alphabet = xrand.AlphanumAlphabet
+			}
+			results := make(map[string]struct{})
+			for i := 0; i < 200; i++ {
+				// act
+				result, err := subject(test.inputLength, test.inputAlphabet)
+
+				// assert
+				assertNil(t, err)
+				assertEqual(t, test.inputLength, len(result))
+				seen := make(map[rune]struct{}, len(result))
+				for _, char := range result {
+					assertTrue(t, strings.ContainsRune(alphabet, char))
+					_, repeated := seen[char]
+					assertTrue(t, !repeated)
+					seen[char] = struct{}{}
+				}
+				results[result] = struct{}{}
+			}
+			assertEqual(t, test.inputLength > 0, len(results) > 1)
+		})
+	}
+
+	t.Run("not enough distinct characters", func(t *testing.T) {
+		t.Parallel()
+
+		// act
+		result, err := subject(4, "aabbbc")
+
+		// assert
+		assertTrue(t, errors.Is(err, xrand.ErrNotEnoughDistinctChars))
+		assertEqual(t, "", result)
+	})
+}
+
+func TestReadableString(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	subject := xrand.ReadableString
+
+	for _, testData := range [...]int{0, 1, 6, 16, 100} {
+		n := testData // capture range variable
+		t.Run(fmt.Sprintf("len = %d", n), func(t *testing.T) {
+			for i := 0; i < 1000; i++ {
+				// act
+				result := subject(n)
+
+				// assert
+				assertEqual(t, n, len(result))
+				assertTrue(t, !strings.ContainsAny(result, "ILOUilou"))
+				for _, char := range result {
+					assertTrue(t, strings.ContainsRune(xrand.Base32CrockfordAlphabet, char))
+				}
+			}
+		})
+	}
+}
+
+func TestPrefixedString(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		tests = [...]struct {
+			name          string
+			inputPrefix   string
+			inputLength   int
+			inputAlphabet []string
+			expectedReg   *regexp.Regexp
+		}{
+			{
+				name:        "default alphabet",
+				inputPrefix: "usr_",
+				inputLength: 12,
+				expectedReg: regexp.MustCompile(`^usr_[a-z0-9]{12}$`),
+			},
+			{
+				name:          "digits alphabet",
+				inputPrefix:   "order-",
+				inputLength:   8,
+				inputAlphabet: []string{xrand.DigitsAlphabet},
+				expectedReg:   regexp.MustCompile(`^order-[0-9]{8}$`),
+			},
+			{
+				name:        "empty prefix",
+				inputPrefix: "",
+				inputLength: 5,
+				expectedReg: regexp.MustCompile(`^[a-z0-9]{5}$`),
+			},
+			{
+				name:        "len = 0",
+				inputPrefix: "key_",
+				inputLength: 0,
+				expectedReg: regexp.MustCompile(`^key_$`),
+			},
+		}
+	)
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			generator := xrand.NewPrefixedGenerator(test.inputPrefix, test.inputLength, test.inputAlphabet...)
+			for i := 0; i < 100; i++ {
+				// act
+				result1 := xrand.PrefixedString(test.inputPrefix, test.inputLength, test.inputAlphabet...)
+				result2 := generator.Generate()
+
+				// assert
+				for _, result := range [...]string{result1, result2} {
+					assertEqual(t, len(test.inputPrefix)+test.inputLength, len(result))
+					assertTrue(t, strings.HasPrefix(result, test.inputPrefix))
+					assertTrue(t, test.expectedReg.MatchString(result))
+				}
+			}
+		})
+	}
+}
+
+func TestStringPadded(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	tests := [...]struct {
+		name          string
+		inputLength   int
+		inputWidth    int
+		inputFill     byte
+		inputAlphabet []string
+		expectedReg   *regexp.Regexp
+		expectedLeft  *regexp.Regexp
+	}{
+		{
+			name:         "default alphabet",
+			inputLength:  5,
+			inputWidth:   8,
+			inputFill:    '_',
+			expectedReg:  regexp.MustCompile(`^[a-z0-9]{5}___$`),
+			expectedLeft: regexp.MustCompile(`^___[a-z0-9]{5}$`),
+		},
+		{
+			name:          "digits alphabet",
+			inputLength:   3,
+			inputWidth:    10,
+			inputFill:     ' ',
+			inputAlphabet: []string{xrand.DigitsAlphabet},
+			expectedReg:   regexp.MustCompile(`^[0-9]{3} {7}$`),
+			expectedLeft:  regexp.MustCompile(`^ {7}[0-9]{3}$`),
+		},
+		{
+			name:         "n = width",
+			inputLength:  6,
+			inputWidth:   6,
+			inputFill:    '_',
+			expectedReg:  regexp.MustCompile(`^[a-z0-9]{6}$`),
+			expectedLeft: regexp.MustCompile(`^[a-z0-9]{6}$`),
+		},
+		{
+			name:         "n > width, unpadded",
+			inputLength:  6,
+			inputWidth:   2,
+			inputFill:    '_',
+			expectedReg:  regexp.MustCompile(`^[a-z0-9]{6}$`),
+			expectedLeft: regexp.MustCompile(`^[a-z0-9]{6}$`),
+		},
+		{
+			name:         "n = 0, only padding",
+			inputLength:  0,
+			inputWidth:   4,
+			inputFill:    '0',
+			expectedReg:  regexp.MustCompile(`^0000$`),
+			expectedLeft: regexp.MustCompile(`^0000$`),
+		},
+	}
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			expectedLen := test.inputWidth
+			if test.inputLength > expectedLen {
+				expectedLen = test.inputLength
+			}
+			for i := 0; i < 100; i++ {
+				// act
+				result := xrand.StringPadded(test.inputLength, test.inputWidth, test.inputFill, test.inputAlphabet...)
+				resultLeft := xrand.StringPaddedLeft(
+					test.inputLength, test.inputWidth, test.inputFill, test.inputAlphabet...,
+				)
+
+				// assert
+				assertEqual(t, expectedLen, len(result))
+				assertEqual(t, expectedLen, len(resultLeft))
+				assertTrue(t, test.expectedReg.MatchString(result))
+				assertTrue(t, test.expectedLeft.MatchString(resultLeft))
+			}
+		})
+	}
+}
+
+func TestSegmentedID(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject = xrand.SegmentedID
+		tests   = [...]struct {
+			name          string
+			inputSegments []int
+			inputSep      string
+			inputAlphabet []string
+			expectedReg   *regexp.Regexp
+		}{
+			{
+				name:          "even segments",
+				inputSegments: []int{4, 4, 4},
+				inputSep:      "-",
+				inputAlphabet: []string{xrand.Base32CrockfordAlphabet},
+				expectedReg:   regexp.MustCompile(`^[0-9A-HJKMNP-TV-Z]{4}-[0-9A-HJKMNP-TV-Z]{4}-[0-9A-HJKMNP-TV-Z]{4}$`),
+			},
+			{
+				name:          "uneven segments, UUID like layout",
+				inputSegments: []int{8, 4, 4, 4, 12},
+				inputSep:      "-",
+				inputAlphabet: []string{"0123456789abcdef"},
+				expectedReg:   regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`),
+			},
+			{
+				name:          "multi-byte separator, default alphabet",
+				inputSegments: []int{1, 3, 2},
+				inputSep:      "::",
+				expectedReg:   regexp.MustCompile(`^[a-z0-9]::[a-z0-9]{3}::[a-z0-9]{2}$`),
+			},
+			{
+				name:          "empty separator",
+				inputSegments: []int{3, 5},
+				inputSep:      "",
+				inputAlphabet: []string{xrand.DigitsAlphabet},
+				expectedReg:   regexp.MustCompile(`^[0-9]{8}$`),
+			},
+			{
+				name:          "single segment",
+				inputSegments: []int{6},
+				inputSep:      "-",
+				expectedReg:   regexp.MustCompile(`^[a-z0-9]{6}$`),
+			},
+			{
+				name:          "zero length segment",
+				inputSegments: []int{2, 0, 2},
+				inputSep:      "-",
+				expectedReg:   regexp.MustCompile(`^[a-z0-9]{2}--[a-z0-9]{2}$`),
+			},
+			{
+				name:          "no segments",
+				inputSegments: []int{},
+				inputSep:      "-",
+				expectedReg:   regexp.MustCompile(`^$`),
+			},
+		}
+	)
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			for i := 0; i < 100; i++ {
+				// act
+				result := subject(test.inputSegments, test.inputSep, test.inputAlphabet...)
+
+				// assert
+				assertTrue(t, test.expectedReg.MatchString(result))
+			}
+		})
+	}
+
+	t.Run("panics for negative segment", func(t *testing.T) {
+		t.Parallel()
+
+		assertPanics(t, func() { _ = subject([]int{4, -1}, "-") })
+	})
+}
+
+func BenchmarkPrefixedGenerator(b *testing.B) {
+	generator := xrand.NewPrefixedGenerator("usr_", 16)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = generator.Generate()
+	}
+}
+
+func ExampleRandomCase() {
+	// randomly upper/lower case each letter.
+	fmt.Println(xrand.RandomCase("Hello, World!"))
+}
+
+func ExampleStringRejectionRate() {
+	// an alphabet of 33 letters wastes almost half of the random draws, while one of 32 wastes none.
+	fmt.Println(xrand.StringRejectionRate("ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456"))
+	fmt.Println(xrand.StringRejectionRate("ABCDEFGHIJKLMNOPQRSTUVWXYZ012345"))
+	// Output:
+	// 0.484375
+	// 0
+}
+
+func ExampleStringMinEntropy() {
+	// generate a random string with at least 128 bits of entropy.
+	token, err := xrand.StringMinEntropy(128, xrand.AlphanumAlphabet)
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+	fmt.Println(token, xrand.StringEntropyBits(len(token), xrand.AlphanumAlphabet))
+}
+
+func ExampleDistinctChars() {
+	// generate a short code, without repeated characters.
+	code, err := xrand.DistinctChars(6, xrand.Base32CrockfordAlphabet)
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+	fmt.Println(code)
+}
+
+func ExampleReadableString() {
+	// generate an account recovery code, easy to read aloud.
+	code := xrand.ReadableString(10)
+	fmt.Println(code[:5] + "-" + code[5:])
+}
+
+func ExamplePrefixedGenerator() {
+	// generate namespaced IDs, like "usr_a1b2c3d4e5f6".
+	userIDs := xrand.NewPrefixedGenerator("usr_", 12)
+	for i := 0; i < 3; i++ {
+		fmt.Println(userIDs.Generate())
+	}
+}
+
+func ExampleStringPadded() {
+	// a random core in a fixed width column.
+	fmt.Printf("|%s|\n", xrand.StringPadded(6, 10, ' ')) // like |x3k9qa    |
+}
+
+func ExampleStringPaddedLeft() {
+	// a zero-padded random number.
+	fmt.Println(xrand.StringPaddedLeft(4, 8, '0', xrand.DigitsAlphabet)) // like 00004821
+}
+
+func ExampleSegmentedID() {
+	// generate a license key like "7G4K-Q2ZD-89XA-PT3M".
+	key := xrand.SegmentedID([]int{4, 4, 4, 4}, "-", xrand.Base32CrockfordAlphabet)
+	fmt.Println(key)
+}
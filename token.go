@@ -0,0 +1,61 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// URLToken generates byteLen random bytes and returns them encoded with
+// unpadded URL-safe base64 ([base64.RawURLEncoding]).
+// Returned token has exactly ceil(byteLen*8/6) characters from [a-zA-Z0-9-_].
+// It uses math rand, so the result is not suitable for cryptographic purposes;
+// see [SecureURLToken] for that.
+func URLToken(byteLen int) string {
+	b := make([]byte, byteLen)
+	_ = readMathRand(b)
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// HexString generates byteLen random bytes and returns them hex encoded, lowercase.
+// Returned string has exactly 2*byteLen characters from [0-9a-f], and decodes back to byteLen bytes,
+// being suitable for fixtures of raw data, like hex dumps, checksums.
+// It uses math rand, so the result is not suitable for cryptographic purposes.
+func HexString(byteLen int) string {
+	b := make([]byte, byteLen)
+	_ = readMathRand(b)
+
+	return hex.EncodeToString(b)
+}
+
+// SecureURLToken generates byteLen cryptographically secure random bytes and returns them encoded
+// with unpadded URL-safe base64 ([base64.RawURLEncoding]).
+// Returned token has exactly ceil(byteLen*8/6) characters from [a-zA-Z0-9-_].
+// An error is returned if reading from crypto/rand fails.
+func SecureURLToken(byteLen int) (string, error) {
+	b := make([]byte, byteLen)
+	if err := readCryptoRand(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// APIKey generates a cryptographically secure random key holding at least bits bits of entropy,
+// and returns it encoded with unpadded URL-safe base64 ([base64.RawURLEncoding]).
+// The no. of random bytes is bits/8, rounded up. This way, the caller specifies the
+// security strength of the key, rather than its length.
+// An error is returned if reading from crypto/rand fails.
+// It panics if bits <= 0.
+func APIKey(bits int) (string, error) {
+	if bits <= 0 {
+		panic("invalid argument to APIKey")
+	}
+
+	return SecureURLToken((bits + 7) / 8)
+}
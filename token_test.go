@@ -0,0 +1,174 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand_test
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/actforgood/xrand"
+)
+
+// urlSafeBase64Reg matches unpadded URL-safe base64 strings.
+var urlSafeBase64Reg = regexp.MustCompile(`^[a-zA-Z0-9\-_]*$`)
+
+func TestURLToken(t *testing.T) {
+	t.Parallel()
+
+	testURLToken(t, func(byteLen int) (string, error) {
+		return xrand.URLToken(byteLen), nil
+	})
+}
+
+func TestSecureURLToken(t *testing.T) {
+	t.Parallel()
+
+	testURLToken(t, xrand.SecureURLToken)
+}
+
+func testURLToken(t *testing.T, subject func(int) (string, error)) {
+	t.Helper()
+
+	for _, testData := range [...]int{0, 1, 2, 3, 16, 31, 32, 100} {
+		byteLen := testData // capture range variable
+		t.Run(fmt.Sprintf("byteLen = %d", byteLen), func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				// act
+				result, err := subject(byteLen)
+
+				// assert
+				assertNil(t, err)
+				assertTrue(t, urlSafeBase64Reg.MatchString(result))
+				assertEqual(t, (byteLen*8+5)/6, len(result))
+				decoded, err := base64.RawURLEncoding.DecodeString(result)
+				assertNil(t, err)
+				assertEqual(t, byteLen, len(decoded))
+			}
+		})
+	}
+}
+
+func TestHexString(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	hexReg := regexp.MustCompile(`^[0-9a-f]*$`)
+
+	for _, testData := range [...]int{0, 1, 2, 7, 16, 100} {
+		byteLen := testData // capture range variable
+		t.Run(fmt.Sprintf("byteLen = %d", byteLen), func(t *testing.T) {
+			t.Parallel()
+
+			for i := 0; i < 100; i++ {
+				// act
+				result := xrand.HexString(byteLen)
+
+				// assert
+				assertEqual(t, 2*byteLen, len(result))
+				assertTrue(t, hexReg.MatchString(result))
+				decoded, err := hex.DecodeString(result)
+				assertNil(t, err)
+				assertEqual(t, byteLen, len(decoded))
+			}
+		})
+	}
+}
+
+func TestAPIKey(t *testing.T) {
+	t.Parallel()
+
+	for _, testData := range [...]int{1, 7, 8, 9, 64, 127, 128, 256} {
+		bits := testData // capture range variable
+		t.Run(fmt.Sprintf("bits = %d", bits), func(t *testing.T) {
+			t.Parallel()
+
+			// act
+			result, err := xrand.APIKey(bits)
+
+			// assert
+			assertNil(t, err)
+			assertTrue(t, urlSafeBase64Reg.MatchString(result))
+			decoded, err := base64.RawURLEncoding.DecodeString(result)
+			assertNil(t, err)
+			assertTrue(t, len(decoded)*8 >= bits)
+			assertTrue(t, len(decoded)*8 < bits+8) // rounded up to the next byte only
+		})
+	}
+
+	t.Run("keys do not collide", func(t *testing.T) {
+		t.Parallel()
+
+		// arrange
+		keys := make(map[string]struct{}, 10000)
+
+		for i := 0; i < 10000; i++ {
+			// act
+			result, err := xrand.APIKey(128)
+
+			// assert
+			assertNil(t, err)
+			_, found := keys[result]
+			assertTrue(t, !found)
+			keys[result] = struct{}{}
+		}
+	})
+
+	t.Run("panics for non-positive bits", func(t *testing.T) {
+		t.Parallel()
+
+		assertPanics(t, func() { _, _ = xrand.APIKey(0) })
+		assertPanics(t, func() { _, _ = xrand.APIKey(-8) })
+	})
+}
+
+func TestAPIKeyError(t *testing.T) { // Note: not parallel, as it replaces the global crypto reader.
+	// arrange
+	defer xrand.SetCryptoReader(errReader{})()
+
+	// act
+	result, err := xrand.APIKey(128)
+
+	// assert
+	assertTrue(t, errors.Is(err, errEntropy))
+	assertEqual(t, "", result)
+}
+
+func ExampleURLToken() {
+	// generate an opaque token of 16 random bytes, to be used in an url.
+	token := xrand.URLToken(16)
+	fmt.Println("https://example.com/reset?token=" + token)
+}
+
+func ExampleHexString() {
+	// generate a random, checksum like, fixture.
+	fmt.Println(xrand.HexString(20)) // like 3f7a...e1 (40 characters)
+}
+
+func ExampleSecureURLToken() {
+	// generate a secure opaque token of 32 random bytes, to be used in an url.
+	token, err := xrand.SecureURLToken(32)
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+	fmt.Println("https://example.com/reset?token=" + token)
+}
+
+func ExampleAPIKey() {
+	// generate an API key with 256 bits of security strength.
+	key, err := xrand.APIKey(256)
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+	fmt.Println("API key:", key)
+}
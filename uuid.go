@@ -0,0 +1,78 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand
+
+import "encoding/hex"
+
+// UUIDv7Bytes generates a version 7 UUID, according to RFC 9562, and returns its raw 16 bytes.
+// A version 7 UUID embeds the current Unix timestamp in milliseconds in its first 48 bits,
+// followed by 74 random bits (the remaining 6 bits designate the version and variant),
+// so UUIDs generated in different milliseconds sort by creation time.
+// It uses math rand for the random bits, so the result is not suitable for cryptographic purposes.
+func UUIDv7Bytes() [16]byte {
+	var (
+		uuid [16]byte
+		ms   = uint64(now().UnixMilli())
+	)
+
+	// 48 bits big-endian Unix timestamp in milliseconds.
+	uuid[0] = byte(ms >> 40)
+	uuid[1] = byte(ms >> 32)
+	uuid[2] = byte(ms >> 24)
+	uuid[3] = byte(ms >> 16)
+	uuid[4] = byte(ms >> 8)
+	uuid[5] = byte(ms)
+
+	// random bits.
+	_ = readMathRand(uuid[6:])
+
+	uuid[6] = uuid[6]&0x0f | 0x70 // version 7 (0111b)
+	uuid[8] = uuid[8]&0x3f | 0x80 // variant 10b
+
+	return uuid
+}
+
+// UUIDv7 generates a version 7 UUID, according to RFC 9562, and returns its
+// canonical string representation, like "01906e3e-2a4b-7c3d-8e9f-0a1b2c3d4e5f".
+// See [UUIDv7Bytes] for more details.
+func UUIDv7() string {
+	return formatUUID(UUIDv7Bytes())
+}
+
+// SecureUUIDv4 generates a version 4 UUID, according to RFC 9562, and returns its
+// canonical string representation, like "9b2f0c4e-5a1d-4e3b-8f7a-6c5d4e3b2a19".
+// A version 4 UUID has 122 random bits (the remaining 6 bits designate the version and variant),
+// which are read from crypto/rand, so the result is unpredictable and collision-safe,
+// even under adversarial conditions.
+// An error is returned if reading from crypto/rand fails.
+func SecureUUIDv4() (string, error) {
+	var uuid [16]byte
+	if err := readCryptoRand(uuid[:]); err != nil {
+		return "", err
+	}
+
+	uuid[6] = uuid[6]&0x0f | 0x40 // version 4 (0100b)
+	uuid[8] = uuid[8]&0x3f | 0x80 // variant 10b
+
+	return formatUUID(uuid), nil
+}
+
+// formatUUID returns the canonical string representation
+// (lowercase hex, 8-4-4-4-12 groups) of an UUID.
+func formatUUID(uuid [16]byte) string {
+	var b [36]byte
+	hex.Encode(b[0:8], uuid[0:4])
+	b[8] = '-'
+	hex.Encode(b[9:13], uuid[4:6])
+	b[13] = '-'
+	hex.Encode(b[14:18], uuid[6:8])
+	b[18] = '-'
+	hex.Encode(b[19:23], uuid[8:10])
+	b[23] = '-'
+	hex.Encode(b[24:], uuid[10:])
+
+	return string(b[:])
+}
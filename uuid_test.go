@@ -0,0 +1,196 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/actforgood/xrand"
+)
+
+// uuidReg matches a canonical UUID string.
+var uuidReg = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+func TestUUIDv7(t *testing.T) {
+	t.Parallel()
+
+	t.Run("version, variant and format", testUUIDv7Format)
+	t.Run("embedded timestamp is roughly now", testUUIDv7Timestamp)
+	t.Run("uuids are ordered by creation time", testUUIDv7Ordering)
+	t.Run("uuids are unique", testUUIDv7Uniqueness)
+}
+
+func testUUIDv7Format(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 1000; i++ {
+		// act
+		uuid := xrand.UUIDv7()
+
+		// assert
+		assertTrue(t, uuidReg.MatchString(uuid))
+		assertEqual(t, byte('7'), uuid[14])                         // version nibble
+		assertTrue(t, strings.ContainsRune("89ab", rune(uuid[19]))) // variant 10b
+	}
+}
+
+func testUUIDv7Timestamp(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	before := time.Now().UnixMilli()
+
+	// act
+	uuid := xrand.UUIDv7Bytes()
+
+	// assert
+	after := time.Now().UnixMilli()
+	ms := int64(uuid[0])<<40 | int64(uuid[1])<<32 | int64(uuid[2])<<24 |
+		int64(uuid[3])<<16 | int64(uuid[4])<<8 | int64(uuid[5])
+	assertTrue(t, ms >= before)
+	assertTrue(t, ms <= after)
+	assertEqual(t, byte(0x70), uuid[6]&0xf0)
+	assertEqual(t, byte(0x80), uuid[8]&0xc0)
+	assertEqual(t, hex.EncodeToString(uuid[:4]), fmt.Sprintf("%08x", ms>>16))
+}
+
+func testUUIDv7Ordering(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	previous := xrand.UUIDv7()
+	for i := 0; i < 5; i++ {
+		time.Sleep(2 * time.Millisecond)
+
+		// act
+		current := xrand.UUIDv7()
+
+		// assert
+		assertTrue(t, previous < current)
+		previous = current
+	}
+}
+
+func testUUIDv7Uniqueness(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	seen := make(map[string]struct{}, 10000)
+
+	for i := 0; i < 10000; i++ {
+		// act
+		uuid := xrand.UUIDv7()
+
+		// assert
+		_, found := seen[uuid]
+		assertTrue(t, !found)
+		seen[uuid] = struct{}{}
+	}
+}
+
+func TestUUIDv7WithFixedClock(t *testing.T) { // Note: not parallel, as it replaces the global clock.
+	// arrange
+	fixedNow := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC) // 0x018f34069e00 ms
+	defer xrand.SetNow(func() time.Time { return fixedNow })()
+
+	// act
+	uuid1 := xrand.UUIDv7()
+	uuid2 := xrand.UUIDv7()
+
+	// assert
+	assertEqual(t, "018f3406-9e00-7", uuid1[:15])
+	assertEqual(t, uuid1[:15], uuid2[:15])
+	assertTrue(t, uuid1 != uuid2) // random bits still differ.
+}
+
+func TestSecureUUIDv4(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	seen := make(map[string]struct{}, 1000)
+
+	for i := 0; i < 1000; i++ {
+		// act
+		uuid, err := xrand.SecureUUIDv4()
+
+		// assert
+		assertNil(t, err)
+		assertTrue(t, uuidReg.MatchString(uuid))
+		assertEqual(t, byte('4'), uuid[14])                         // version nibble
+		assertTrue(t, strings.ContainsRune("89ab", rune(uuid[19]))) // variant 10b
+		_, found := seen[uuid]
+		assertTrue(t, !found)
+		seen[uuid] = struct{}{}
+	}
+}
+
+func TestSecureUUIDv4Bits(t *testing.T) { // Note: not parallel, as it replaces the global crypto reader.
+	// arrange
+	restore := xrand.SetCryptoReader(bytes.NewReader(bytes.Repeat([]byte{0xFF}, 16)))
+
+	// act
+	result, err := xrand.SecureUUIDv4()
+
+	// assert
+	assertNil(t, err)
+	assertEqual(t, "ffffffff-ffff-4fff-bfff-ffffffffffff", result)
+	restore()
+
+	// arrange
+	defer xrand.SetCryptoReader(bytes.NewReader(make([]byte, 16)))()
+
+	// act
+	result, err = xrand.SecureUUIDv4()
+
+	// assert
+	assertNil(t, err)
+	assertEqual(t, "00000000-0000-4000-8000-000000000000", result)
+}
+
+func TestSecureUUIDv4Error(t *testing.T) { // Note: not parallel, as it replaces the global crypto reader.
+	// arrange
+	defer xrand.SetCryptoReader(errReader{})()
+
+	// act
+	result, err := xrand.SecureUUIDv4()
+
+	// assert
+	assertTrue(t, errors.Is(err, errEntropy))
+	assertEqual(t, "", result)
+}
+
+func BenchmarkUUIDv7(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = xrand.UUIDv7()
+	}
+}
+
+func ExampleUUIDv7() {
+	// generate a time ordered UUID, suitable as database key.
+	id := xrand.UUIDv7()
+	fmt.Println(id)
+}
+
+func ExampleSecureUUIDv4() {
+	// generate an unpredictable UUID, suitable as session identifier.
+	id, err := xrand.SecureUUIDv4()
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+	fmt.Println(id)
+}
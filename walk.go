@@ -0,0 +1,57 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand
+
+import "sync"
+
+// RandomWalk generates a simple random walk: at each call, the current position
+// moves up or down by a fixed step, with equal probability.
+// It is useful, for example, to simulate a fluctuating metric / price.
+// It is safe for concurrent use by multiple goroutines.
+type RandomWalk struct {
+	mu       sync.Mutex
+	start    float64
+	step     float64
+	position float64
+}
+
+// NewRandomWalk instantiates a new RandomWalk, starting at start position, and moving by step.
+func NewRandomWalk(start, step float64) *RandomWalk {
+	return &RandomWalk{
+		start:    start,
+		step:     step,
+		position: start,
+	}
+}
+
+// Next moves the position up or down by step, and returns the new position.
+func (w *RandomWalk) Next() float64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if globalRand.Int63()&1 == 1 {
+		w.position += w.step
+	} else {
+		w.position -= w.step
+	}
+
+	return w.position
+}
+
+// Position returns the current position.
+func (w *RandomWalk) Position() float64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.position
+}
+
+// Reset moves the position back to the start one.
+func (w *RandomWalk) Reset() {
+	w.mu.Lock()
+	w.position = w.start
+	w.mu.Unlock()
+}
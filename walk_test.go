@@ -0,0 +1,77 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/actforgood/xrand"
+)
+
+func TestRandomWalk(t *testing.T) {
+	t.Parallel()
+
+	t.Run("moves by step", testRandomWalkMovesByStep)
+	t.Run("reset", testRandomWalkReset)
+}
+
+func testRandomWalkMovesByStep(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject          = xrand.NewRandomWalk(100, 0.5)
+		prev             = subject.Position()
+		increases, drops int
+	)
+	assertEqual(t, 100.0, prev)
+
+	for i := 0; i < 1000; i++ {
+		// act
+		result := subject.Next()
+
+		// assert
+		assertTrue(t, math.Abs(math.Abs(result-prev)-0.5) < 1e-9)
+		if result > prev {
+			increases++
+		} else {
+			drops++
+		}
+		assertEqual(t, result, subject.Position())
+		prev = result
+	}
+	assertTrue(t, increases > 400)
+	assertTrue(t, drops > 400)
+}
+
+func testRandomWalkReset(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	subject := xrand.NewRandomWalk(-3, 1)
+	for i := 0; i < 11; i++ { // odd no. of steps, walk cannot be back at start.
+		_ = subject.Next()
+	}
+	assertTrue(t, subject.Position() != -3)
+
+	// act
+	subject.Reset()
+
+	// assert
+	assertEqual(t, -3.0, subject.Position())
+	result := subject.Next()
+	assertTrue(t, result == -4 || result == -2)
+}
+
+func ExampleRandomWalk() {
+	// simulate a fluctuating price.
+	price := xrand.NewRandomWalk(100, 0.25)
+	for i := 0; i < 5; i++ {
+		fmt.Printf("%.2f\n", price.Next())
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+var (
+	// ErrInvalidWeight is returned when a weight is negative, NaN or infinite.
+	ErrInvalidWeight = errors.New("xrand: weights must be finite, non-negative numbers")
+	// ErrZeroTotalWeight is returned when there is nothing to pick from, weights are empty or all zero.
+	ErrZeroTotalWeight = errors.New("xrand: total weight must be positive")
+	// ErrWeightsLength is returned when items and their weights have different lengths.
+	ErrWeightsLength = errors.New("xrand: items and weights must have the same length")
+)
+
+// WeightedPickNormalized returns a random element from items, picked with
+// a probability proportional to its weight, weights[i] / sum(weights).
+// Weights do not need to sum to 1, raw values like counts ([3, 1, 1]) can be passed,
+// as they are normalized internally.
+// It returns [ErrWeightsLength] if items and weights have different lengths,
+// [ErrInvalidWeight] if a weight is negative (or NaN / infinite),
+// [ErrZeroTotalWeight] if items are empty or all weights are zero.
+func WeightedPickNormalized[T any](items []T, weights []float64) (T, error) {
+	var zero T
+	if len(items) != len(weights) {
+		return zero, ErrWeightsLength
+	}
+	total, err := totalWeight(weights)
+	if err != nil {
+		return zero, err
+	}
+
+	return items[pickWeightedIndex(weights, total)], nil
+}
+
+// SampleIndexByWeight returns a random index of weights, picked with a probability
+// proportional to its weight, weights[i] / sum(weights).
+// It is the index returning counterpart of [WeightedPickNormalized], useful for indexing
+// into parallel slices. An index with zero weight is never picked.
+// It returns [ErrInvalidWeight] if a weight is negative (or NaN / infinite),
+// [ErrZeroTotalWeight] if weights are empty or all zero.
+func SampleIndexByWeight(weights []float64) (int, error) {
+	total, err := totalWeight(weights)
+	if err != nil {
+		return -1, err
+	}
+
+	return pickWeightedIndex(weights, total), nil
+}
+
+// PickBy returns a random element from items, picked with a probability proportional
+// to its weight, computed with the weight callback, weight(items[i]) / sum(weight(items)).
+// It saves the caller from building a weights slice parallel to items, see [WeightedPickNormalized].
+// The weight callback is called once for each item.
+// It returns [ErrInvalidWeight] if a weight is negative (or NaN / infinite),
+// [ErrZeroTotalWeight] if items are empty or all weights are zero.
+func PickBy[T any](items []T, weight func(T) float64) (T, error) {
+	weights := make([]float64, len(items))
+	for idx, item := range items {
+		weights[idx] = weight(item)
+	}
+
+	return WeightedPickNormalized(items, weights)
+}
+
+// WeightedOrder returns a new slice with all the items, in a random order where items with higher
+// weights tend toward the front, like search results shuffled with relevance weights.
+// Each item gets an Efraimidis-Spirakis key, u^(1/weight), u being uniformly random in (0,1),
+// and items are sorted by descending keys; thus the first item is picked with a probability
+// proportional to its weight, the second one likewise among the remaining items, and so on.
+// Items with zero weight come last, in a uniformly random order. Input items are not modified.
+// It panics if items and weights have different lengths, or a weight is negative (or NaN / infinite).
+func WeightedOrder[T any](items []T, weights []float64) []T {
+	if len(items) != len(weights) {
+		panic("invalid argument to WeightedOrder")
+	}
+
+	var (
+		keys    = make([]float64, len(items))
+		indices = globalRand.Perm(len(items)) // Note: random order of ties (zero weights).
+	)
+	for idx, weight := range weights {
+		if !(weight >= 0) || math.IsInf(weight, 1) { // Note: negated condition also catches NaN.
+			panic("invalid argument to WeightedOrder")
+		}
+		// Note: log(u)/weight is used as key, which preserves the order of u^(1/weight),
+		// while not underflowing for small weights.
+		keys[idx] = math.Inf(-1)
+		if weight > 0 {
+			keys[idx] = math.Log(Float64Open()) / weight
+		}
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		return keys[indices[i]] > keys[indices[j]]
+	})
+
+	ordered := make([]T, len(items))
+	for i, idx := range indices {
+		ordered[i] = items[idx]
+	}
+
+	return ordered
+}
+
+// WeightedCase is a behavior, run by [WeightedDispatch] with a probability proportional to its weight.
+type WeightedCase struct {
+	// Weight is the case's weight, relative to the other cases.
+	Weight float64
+	// Fn is the behavior to run, if the case is picked.
+	Fn func()
+}
+
+// WeightedDispatch picks one of the cases, with a probability proportional to its weight,
+// and runs its function. It is useful for fault-injection harnesses, like:
+//
+//	_ = xrand.WeightedDispatch([]xrand.WeightedCase{
+//		{Weight: 90, Fn: respondOK},
+//		{Weight: 8, Fn: respondSlowly},
+//		{Weight: 2, Fn: respondWithError},
+//	})
+//
+// A case with zero weight never runs.
+// It returns [ErrInvalidWeight] if a weight is negative (or NaN / infinite),
+// [ErrZeroTotalWeight] if cases are empty or all weights are zero, in which case no function runs.
+func WeightedDispatch(cases []WeightedCase) error {
+	weights := make([]float64, len(cases))
+	for idx, c := range cases {
+		weights[idx] = c.Weight
+	}
+	total, err := totalWeight(weights)
+	if err != nil {
+		return err
+	}
+	cases[pickWeightedIndex(weights, total)].Fn()
+
+	return nil
+}
+
+// WeightedTree is a node of a tree, used for hierarchical sampling, like picking a category, then a subcategory.
+// See [WeightedTree.Sample].
+type WeightedTree struct {
+	// Name is the node's name, returned in sampled paths.
+	Name string
+	// Weight is the node's weight, relative to its siblings. Root's weight is not used.
+	Weight float64
+	// Children are the node's children. A node without children is a leaf.
+	Children []*WeightedTree
+}
+
+// Sample walks the tree from root to a leaf, picking at each level a child with a probability
+// proportional to its weight, and returns the names of the visited nodes, root's included.
+// Thus, a leaf is reached with the product of the (normalized) weights on its path.
+// It panics if a node has children with an invalid weight (negative, NaN / infinite) or all zero weights.
+func (tree *WeightedTree) Sample() []string {
+	path := []string{tree.Name}
+	for node := tree; len(node.Children) > 0; {
+		weights := make([]float64, len(node.Children))
+		for idx, child := range node.Children {
+			weights[idx] = child.Weight
+		}
+		total, err := totalWeight(weights)
+		if err != nil {
+			panic("invalid weights in WeightedTree: " + err.Error())
+		}
+		node = node.Children[pickWeightedIndex(weights, total)]
+		path = append(path, node.Name)
+	}
+
+	return path
+}
+
+// totalWeight validates the weights and returns their sum.
+func totalWeight(weights []float64) (float64, error) {
+	var total float64
+	for _, weight := range weights {
+		if !(weight >= 0) || math.IsInf(weight, 1) { // Note: negated condition also catches NaN.
+			return 0, ErrInvalidWeight
+		}
+		total += weight
+	}
+	if !(total > 0) || math.IsInf(total, 1) {
+		return 0, ErrZeroTotalWeight
+	}
+
+	return total, nil
+}
+
+// pickWeightedIndex returns a random index, picked with a probability proportional to its weight.
+// Weights are expected to be valid, with given positive total.
+func pickWeightedIndex(weights []float64, total float64) int {
+	var (
+		r    = Float64() * total
+		last int // last index with positive weight, fallback for float rounding errors.
+	)
+	for idx, weight := range weights {
+		if weight <= 0 {
+			continue
+		}
+		if r < weight {
+			return idx
+		}
+		r -= weight
+		last = idx
+	}
+
+	return last
+}
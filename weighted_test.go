@@ -0,0 +1,662 @@
+// Copyright The ActForGood Authors.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://github.com/actforgood/xrand/blob/main/LICENSE.
+
+package xrand_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/actforgood/xrand"
+)
+
+func TestWeightedPickNormalized(t *testing.T) {
+	t.Parallel()
+
+	t.Run("distribution matches normalized weights", testWeightedPickNormalizedDistribution)
+	t.Run("errors", testWeightedPickNormalizedErrors)
+}
+
+func testWeightedPickNormalizedDistribution(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 50000
+	var (
+		subject = xrand.WeightedPickNormalized[string]
+		tests   = [...]struct {
+			name         string
+			inputItems   []string
+			inputWeights []float64
+		}{
+			{
+				name:         "raw counts",
+				inputItems:   []string{"a", "b", "c"},
+				inputWeights: []float64{3, 1, 1},
+			},
+			{
+				name:         "large weights",
+				inputItems:   []string{"a", "b", "c", "d"},
+				inputWeights: []float64{1000, 2500, 0, 6500},
+			},
+			{
+				name:         "small weights",
+				inputItems:   []string{"a", "b"},
+				inputWeights: []float64{0.001, 0.003},
+			},
+		}
+	)
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			counts := make(map[string]int, len(test.inputItems))
+			for i := 0; i < iterations; i++ {
+				// act
+				result, err := subject(test.inputItems, test.inputWeights)
+
+				// assert
+				assertNil(t, err)
+				counts[result]++
+			}
+			assertWeightedDistribution(t, test.inputItems, test.inputWeights, counts, iterations)
+		})
+	}
+}
+
+func testWeightedPickNormalizedErrors(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject = xrand.WeightedPickNormalized[string]
+		tests   = [...]struct {
+			name         string
+			inputItems   []string
+			inputWeights []float64
+			expectedErr  error
+		}{
+			{
+				name:         "negative weight",
+				inputItems:   []string{"a", "b"},
+				inputWeights: []float64{1, -1},
+				expectedErr:  xrand.ErrInvalidWeight,
+			},
+			{
+				name:         "NaN weight",
+				inputItems:   []string{"a", "b"},
+				inputWeights: []float64{math.NaN(), 1},
+				expectedErr:  xrand.ErrInvalidWeight,
+			},
+			{
+				name:         "infinite weight",
+				inputItems:   []string{"a", "b"},
+				inputWeights: []float64{math.Inf(1), 1},
+				expectedErr:  xrand.ErrInvalidWeight,
+			},
+			{
+				name:         "all zero weights",
+				inputItems:   []string{"a", "b"},
+				inputWeights: []float64{0, 0},
+				expectedErr:  xrand.ErrZeroTotalWeight,
+			},
+			{
+				name:         "empty items",
+				inputItems:   []string{},
+				inputWeights: []float64{},
+				expectedErr:  xrand.ErrZeroTotalWeight,
+			},
+			{
+				name:         "different lengths",
+				inputItems:   []string{"a", "b"},
+				inputWeights: []float64{1},
+				expectedErr:  xrand.ErrWeightsLength,
+			},
+		}
+	)
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			// act
+			result, err := subject(test.inputItems, test.inputWeights)
+
+			// assert
+			assertTrue(t, errors.Is(err, test.expectedErr))
+			assertEqual(t, "", result)
+		})
+	}
+}
+
+func TestSampleIndexByWeight(t *testing.T) {
+	t.Parallel()
+
+	t.Run("distribution matches weights", testSampleIndexByWeightDistribution)
+	t.Run("errors", testSampleIndexByWeightErrors)
+}
+
+func testSampleIndexByWeightDistribution(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 50000
+	var (
+		weights = []float64{0, 5, 0.5, 0, 2.5, 2}
+		indices = []int{0, 1, 2, 3, 4, 5}
+		counts  = make(map[int]int, len(weights))
+	)
+
+	for i := 0; i < iterations; i++ {
+		// act
+		result, err := xrand.SampleIndexByWeight(weights)
+
+		// assert
+		assertNil(t, err)
+		counts[result]++
+	}
+	assertEqual(t, 0, counts[0])
+	assertEqual(t, 0, counts[3])
+	assertWeightedDistribution(t, indices, weights, counts, iterations)
+}
+
+func testSampleIndexByWeightErrors(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	tests := [...]struct {
+		name         string
+		inputWeights []float64
+		expectedErr  error
+	}{
+		{name: "negative weight", inputWeights: []float64{1, -1}, expectedErr: xrand.ErrInvalidWeight},
+		{name: "NaN weight", inputWeights: []float64{math.NaN()}, expectedErr: xrand.ErrInvalidWeight},
+		{name: "all zero weights", inputWeights: []float64{0, 0}, expectedErr: xrand.ErrZeroTotalWeight},
+		{name: "empty weights", inputWeights: []float64{}, expectedErr: xrand.ErrZeroTotalWeight},
+	}
+
+	for _, test := range tests {
+		// act
+		result, err := xrand.SampleIndexByWeight(test.inputWeights)
+
+		// assert
+		assertTrue(t, errors.Is(err, test.expectedErr))
+		assertEqual(t, -1, result)
+	}
+}
+
+func TestPickBy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("distribution matches weight function", testPickByDistribution)
+	t.Run("errors", testPickByErrors)
+}
+
+// testServer is a struct used in PickBy tests.
+type testServer struct {
+	name     string
+	capacity int
+}
+
+func testPickByDistribution(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 50000
+	var (
+		servers = []testServer{
+			{name: "big", capacity: 6},
+			{name: "medium", capacity: 3},
+			{name: "small", capacity: 1},
+			{name: "down", capacity: 0},
+		}
+		weights  = []float64{6, 3, 1, 0}
+		capacity = func(server testServer) float64 {
+			return float64(server.capacity)
+		}
+		counts = make(map[testServer]int, len(servers))
+	)
+
+	for i := 0; i < iterations; i++ {
+		// act
+		result, err := xrand.PickBy(servers, capacity)
+
+		// assert
+		assertNil(t, err)
+		counts[result]++
+	}
+	assertWeightedDistribution(t, servers, weights, counts, iterations)
+}
+
+func testPickByErrors(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		subject = xrand.PickBy[int]
+		tests   = [...]struct {
+			name        string
+			inputItems  []int
+			expectedErr error
+		}{
+			{
+				name:        "negative weight",
+				inputItems:  []int{1, -1},
+				expectedErr: xrand.ErrInvalidWeight,
+			},
+			{
+				name:        "all zero weights",
+				inputItems:  []int{0, 0},
+				expectedErr: xrand.ErrZeroTotalWeight,
+			},
+			{
+				name:        "empty items",
+				inputItems:  []int{},
+				expectedErr: xrand.ErrZeroTotalWeight,
+			},
+			{
+				name:        "nil items",
+				inputItems:  nil,
+				expectedErr: xrand.ErrZeroTotalWeight,
+			},
+		}
+		identity = func(item int) float64 { return float64(item) }
+	)
+
+	for _, testData := range tests {
+		test := testData // capture range variable
+		t.Run(test.name, func(t *testing.T) {
+			// act
+			result, err := subject(test.inputItems, identity)
+
+			// assert
+			assertTrue(t, errors.Is(err, test.expectedErr))
+			assertEqual(t, 0, result)
+		})
+	}
+}
+
+func TestWeightedOrder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("all items are present once", testWeightedOrderIsPermutation)
+	t.Run("first position matches weights", testWeightedOrderFirstDistribution)
+	t.Run("high weight items come first on average", testWeightedOrderAveragePositions)
+	t.Run("panics for invalid weights", testWeightedOrderPanics)
+}
+
+func testWeightedOrderIsPermutation(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		items   = []string{"a", "b", "c", "d", "e"}
+		weights = []float64{5, 0, 1, 0.001, 0}
+	)
+
+	for i := 0; i < 100; i++ {
+		// act
+		result := xrand.WeightedOrder(items, weights)
+
+		// assert
+		assertSamePermutation(t, items, result)
+		// zero weights come last.
+		assertTrue(t, result[3] == "b" || result[3] == "e")
+		assertTrue(t, result[4] == "b" || result[4] == "e")
+	}
+	assertTrue(t, reflect.DeepEqual([]string{"a", "b", "c", "d", "e"}, items)) // input is not modified
+	assertEqual(t, 0, len(xrand.WeightedOrder([]int{}, []float64{})))
+}
+
+func testWeightedOrderFirstDistribution(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 50000
+	var (
+		items   = []string{"a", "b", "c", "d"}
+		weights = []float64{6, 3, 1, 0}
+		counts  = make(map[string]int, len(items))
+	)
+
+	for i := 0; i < iterations; i++ {
+		// act
+		result := xrand.WeightedOrder(items, weights)
+
+		// assert
+		counts[result[0]]++
+	}
+	assertWeightedDistribution(t, items, weights, counts, iterations)
+}
+
+func testWeightedOrderAveragePositions(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 10000
+	var (
+		items     = []int{0, 1, 2, 3, 4}
+		weights   = []float64{1, 16, 4, 2, 8}
+		positions = make([]int, len(items))
+	)
+
+	for i := 0; i < iterations; i++ {
+		// act
+		result := xrand.WeightedOrder(items, weights)
+
+		// assert
+		for pos, item := range result {
+			positions[item] += pos
+		}
+	}
+	// by descending weights: 1, 4, 2, 3, 0
+	assertTrue(t, positions[1] < positions[4])
+	assertTrue(t, positions[4] < positions[2])
+	assertTrue(t, positions[2] < positions[3])
+	assertTrue(t, positions[3] < positions[0])
+}
+
+func testWeightedOrderPanics(t *testing.T) {
+	t.Parallel()
+
+	// act & assert
+	assertPanics(t, func() { _ = xrand.WeightedOrder([]int{1, 2}, []float64{1}) })
+	assertPanics(t, func() { _ = xrand.WeightedOrder([]int{1, 2}, []float64{1, -1}) })
+	assertPanics(t, func() { _ = xrand.WeightedOrder([]int{1, 2}, []float64{1, math.NaN()}) })
+	assertPanics(t, func() { _ = xrand.WeightedOrder([]int{1, 2}, []float64{1, math.Inf(1)}) })
+}
+
+func TestWeightedDispatch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("functions run proportionally to their weights", testWeightedDispatchDistribution)
+	t.Run("errors", testWeightedDispatchErrors)
+}
+
+func testWeightedDispatchDistribution(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 50000
+	var (
+		behaviors = []string{"ok", "slow", "error", "disabled"}
+		weights   = []float64{7, 2, 1, 0}
+		counts    = make(map[string]int, len(behaviors))
+		cases     = make([]xrand.WeightedCase, len(behaviors))
+	)
+	for idx, behavior := range behaviors {
+		behavior := behavior
+		cases[idx] = xrand.WeightedCase{
+			Weight: weights[idx],
+			Fn:     func() { counts[behavior]++ },
+		}
+	}
+
+	for i := 0; i < iterations; i++ {
+		// act
+		err := xrand.WeightedDispatch(cases)
+
+		// assert
+		assertNil(t, err)
+	}
+	assertEqual(t, 0, counts["disabled"])
+	assertWeightedDistribution(t, behaviors, weights, counts, iterations)
+}
+
+func testWeightedDispatchErrors(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var (
+		runs  int
+		fn    = func() { runs++ }
+		tests = [...]struct {
+			name        string
+			inputCases  []xrand.WeightedCase
+			expectedErr error
+		}{
+			{
+				name:        "negative weight",
+				inputCases:  []xrand.WeightedCase{{Weight: 1, Fn: fn}, {Weight: -1, Fn: fn}},
+				expectedErr: xrand.ErrInvalidWeight,
+			},
+			{
+				name:        "NaN weight",
+				inputCases:  []xrand.WeightedCase{{Weight: math.NaN(), Fn: fn}},
+				expectedErr: xrand.ErrInvalidWeight,
+			},
+			{
+				name:        "all zero weights",
+				inputCases:  []xrand.WeightedCase{{Weight: 0, Fn: fn}, {Weight: 0, Fn: fn}},
+				expectedErr: xrand.ErrZeroTotalWeight,
+			},
+			{
+				name:        "no cases",
+				inputCases:  nil,
+				expectedErr: xrand.ErrZeroTotalWeight,
+			},
+		}
+	)
+
+	for _, test := range tests {
+		// act
+		err := xrand.WeightedDispatch(test.inputCases)
+
+		// assert
+		assertTrue(t, errors.Is(err, test.expectedErr))
+	}
+	assertEqual(t, 0, runs)
+}
+
+func TestWeightedTreeSample(t *testing.T) {
+	t.Parallel()
+
+	t.Run("leaf frequencies match product of weights", testWeightedTreeSampleDistribution)
+	t.Run("single node tree", testWeightedTreeSampleSingleNode)
+	t.Run("panics for invalid weights", testWeightedTreeSamplePanics)
+}
+
+func testWeightedTreeSampleDistribution(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	const iterations = 50000
+	var (
+		subject = &xrand.WeightedTree{
+			Name: "products",
+			Children: []*xrand.WeightedTree{
+				{
+					Name:   "books",
+					Weight: 3,
+					Children: []*xrand.WeightedTree{
+						{Name: "fiction", Weight: 2},
+						{Name: "science", Weight: 1},
+					},
+				},
+				{
+					Name:   "music",
+					Weight: 1,
+					Children: []*xrand.WeightedTree{
+						{Name: "rock", Weight: 1},
+						{Name: "jazz", Weight: 1},
+						{Name: "opera", Weight: 0},
+					},
+				},
+				{Name: "gift cards", Weight: 1}, // a leaf on the 1st level
+			},
+		}
+		paths = map[string][]string{
+			"fiction":    {"products", "books", "fiction"},
+			"science":    {"products", "books", "science"},
+			"rock":       {"products", "music", "rock"},
+			"jazz":       {"products", "music", "jazz"},
+			"opera":      {"products", "music", "opera"},
+			"gift cards": {"products", "gift cards"},
+		}
+		leaves  = []string{"fiction", "science", "rock", "jazz", "opera", "gift cards"}
+		weights = []float64{0.6 * 2 / 3, 0.6 / 3, 0.2 / 2, 0.2 / 2, 0, 0.2}
+		counts  = make(map[string]int, len(leaves))
+	)
+
+	for i := 0; i < iterations; i++ {
+		// act
+		result := subject.Sample()
+
+		// assert
+		leaf := result[len(result)-1]
+		assertTrue(t, reflect.DeepEqual(paths[leaf], result))
+		counts[leaf]++
+	}
+	assertWeightedDistribution(t, leaves, weights, counts, iterations)
+}
+
+func testWeightedTreeSampleSingleNode(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	subject := &xrand.WeightedTree{Name: "root"}
+
+	// act
+	result := subject.Sample()
+
+	// assert
+	assertTrue(t, reflect.DeepEqual([]string{"root"}, result))
+}
+
+func testWeightedTreeSamplePanics(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	allZero := &xrand.WeightedTree{
+		Name:     "root",
+		Children: []*xrand.WeightedTree{{Name: "a"}, {Name: "b"}},
+	}
+	negative := &xrand.WeightedTree{
+		Name: "root",
+		Children: []*xrand.WeightedTree{
+			{Name: "a", Weight: 1, Children: []*xrand.WeightedTree{{Name: "a1", Weight: -1}}},
+		},
+	}
+
+	// act & assert
+	assertPanics(t, func() { _ = allZero.Sample() })
+	assertPanics(t, func() { _ = negative.Sample() })
+}
+
+// assertWeightedDistribution checks that the counts of occurrences of each item
+// are proportional to the item's weight (with an absolute tolerance of 1.5%).
+// Returns successful assertion status.
+func assertWeightedDistribution[T comparable](
+	t *testing.T,
+	items []T,
+	weights []float64,
+	counts map[T]int,
+	total int,
+) bool {
+	t.Helper()
+	var totalWeight float64
+	for _, weight := range weights {
+		totalWeight += weight
+	}
+	for idx, item := range items {
+		expected := weights[idx] / totalWeight
+		actual := float64(counts[item]) / float64(total)
+		if math.Abs(expected-actual) > 0.015 || (expected == 0 && actual != 0) {
+			t.Errorf("item %+v: expected rate ~%.3f, but got %.3f", item, expected, actual)
+
+			return false
+		}
+	}
+
+	return true
+}
+
+func ExampleWeightedPickNormalized() {
+	// pick a server, proportionally to its capacity.
+	servers := []string{"big-server", "small-server1", "small-server2"}
+	capacities := []float64{3, 1, 1}
+	server, err := xrand.WeightedPickNormalized(servers, capacities)
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+	fmt.Println(server)
+}
+
+func ExampleSampleIndexByWeight() {
+	// pick a particle by its importance, and read its parallel attributes.
+	var (
+		names       = []string{"p1", "p2", "p3"}
+		positions   = []float64{0.5, 1.7, 3.2}
+		importances = []float64{0.2, 0.5, 0.3}
+	)
+	idx, err := xrand.SampleIndexByWeight(importances)
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+	fmt.Println(names[idx], positions[idx])
+}
+
+func ExamplePickBy() {
+	// pick a server, proportionally to its capacity.
+	type server struct {
+		host     string
+		capacity float64
+	}
+	servers := []server{{"big-server", 3}, {"small-server1", 1}, {"small-server2", 1}}
+	picked, err := xrand.PickBy(servers, func(s server) float64 { return s.capacity })
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+	fmt.Println(picked.host)
+}
+
+func ExampleWeightedOrder() {
+	// shuffle search results, keeping the more relevant ones toward the front.
+	results := []string{"doc1", "doc2", "doc3", "doc4"}
+	relevance := []float64{0.9, 0.5, 0.3, 0.1}
+	fmt.Println(xrand.WeightedOrder(results, relevance)) // like [doc1 doc3 doc2 doc4]
+}
+
+func ExampleWeightedDispatch() {
+	// inject faults in 10% of the requests.
+	err := xrand.WeightedDispatch([]xrand.WeightedCase{
+		{Weight: 90, Fn: func() { fmt.Println("200 OK") }},
+		{Weight: 7, Fn: func() { fmt.Println("503 Service Unavailable") }},
+		{Weight: 3, Fn: func() { fmt.Println("504 Gateway Timeout") }},
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+}
+
+func ExampleWeightedTree_Sample() {
+	// pick a product category, then a subcategory.
+	categories := &xrand.WeightedTree{
+		Name: "products",
+		Children: []*xrand.WeightedTree{
+			{
+				Name:     "books",
+				Weight:   3,
+				Children: []*xrand.WeightedTree{{Name: "fiction", Weight: 2}, {Name: "science", Weight: 1}},
+			},
+			{
+				Name:     "music",
+				Weight:   1,
+				Children: []*xrand.WeightedTree{{Name: "rock", Weight: 1}, {Name: "jazz", Weight: 1}},
+			},
+		},
+	}
+	fmt.Println(categories.Sample()) // like [products books fiction]
+}